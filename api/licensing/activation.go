@@ -0,0 +1,236 @@
+package licensing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// activationNonceSize is the number of random bytes of an activation nonce.
+const activationNonceSize = 16
+
+// DefaultMaxActivations is the default number of installations a license can
+// be activated on by an Issuer.
+const DefaultMaxActivations = 1
+
+var (
+	// ErrActivationRequired is returned when a license requiring activation is
+	// validated without an activation token.
+	ErrActivationRequired = errors.New("license requires activation")
+	// ErrActivationMismatch is returned when an activation token does not
+	// match the license, the installation or the activation request.
+	ErrActivationMismatch = errors.New("activation token does not match")
+	// ErrActivationRefused is returned when an issuer refuses to activate a
+	// license on an installation.
+	ErrActivationRefused = errors.New("activation refused")
+	// ErrNotActivated is returned when an issuer verifies a usage report of
+	// an installation the license was not activated on.
+	ErrNotActivated = errors.New("license is not activated on the installation")
+)
+
+// ActivationRequest is produced by an installation to activate a license
+// requiring activation, without network access to the issuer. It is signed
+// with the installation key and exchanged as a file.
+type ActivationRequest struct {
+	// InstallationID is the fingerprint of the installation.
+	InstallationID string `json:"installationID"`
+	// LicenseID is the ID of the license to activate.
+	LicenseID string `json:"licenseID"`
+	// Nonce is a random value identifying the request, which the activation
+	// token must echo.
+	Nonce string `json:"nonce"`
+	// Created is the time at which the request was created.
+	Created Timestamp `json:"created"`
+	// SignatureOptions contains signature algorithm and related parameters,
+	// which are part of the signed request data.
+	SignatureOptions SignatureOptions `json:"signature"`
+}
+
+// SignedActivationRequest is an activation request along with its signature
+// by the installation key.
+type SignedActivationRequest struct {
+	// Request is the activation request.
+	Request ActivationRequest `json:"request"`
+	// Signature is the signature of the JSON encoded request.
+	Signature []byte `json:"signature"`
+}
+
+// ActivationToken is returned by the issuer in response to an activation
+// request, and activates the license on the installation. It is signed with
+// the key of the issuer.
+type ActivationToken struct {
+	// InstallationID is the fingerprint of the activated installation.
+	InstallationID string `json:"installationID"`
+	// LicenseID is the ID of the activated license.
+	LicenseID string `json:"licenseID"`
+	// Nonce is the nonce of the activation request.
+	Nonce string `json:"nonce"`
+	// Activated is the time at which the license was activated.
+	Activated Timestamp `json:"activated"`
+	// SignatureOptions contains signature algorithm and related parameters,
+	// which are part of the signed token data.
+	SignatureOptions SignatureOptions `json:"signature"`
+}
+
+// SignedActivationToken is an activation token along with its signature by
+// the issuer key.
+type SignedActivationToken struct {
+	// Token is the activation token.
+	Token ActivationToken `json:"token"`
+	// Signature is the signature of the JSON encoded token.
+	Signature []byte `json:"signature"`
+}
+
+// NewActivationRequest creates a request to activate the license on the
+// installation with the given fingerprint.
+func NewActivationRequest(file *LicenseFile, installationID string, created time.Time) (*ActivationRequest, error) {
+	nonce := make([]byte, activationNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// timestamps are serialized with a precision of one second
+	return &ActivationRequest{
+		InstallationID:   installationID,
+		LicenseID:        file.ID(),
+		Nonce:            hex.EncodeToString(nonce),
+		Created:          Timestamp(created.Truncate(time.Second)),
+		SignatureOptions: DefaultSignatureOptions,
+	}, nil
+}
+
+// Sign signs the activation request with the given signer, which must hold
+// the RSA key of the installation.
+func (r *ActivationRequest) Sign(signer crypto.Signer) (*SignedActivationRequest, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signData(data, signer, &r.SignatureOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedActivationRequest{Request: *r, Signature: signature}, nil
+}
+
+// CheckToken verifies the signature of the activation token with the PEM
+// encoded public key of the issuer, and that it answers the request.
+func (r *ActivationRequest) CheckToken(token *SignedActivationToken, issuerPublicKey string) error {
+	if err := token.Verify(issuerPublicKey); err != nil {
+		return fmt.Errorf("invalid activation token signature: %w", err)
+	}
+	if token.Token.Nonce != r.Nonce {
+		return fmt.Errorf("%w: the nonce differs from the activation request", ErrActivationMismatch)
+	}
+	if token.Token.LicenseID != r.LicenseID || token.Token.InstallationID != r.InstallationID {
+		return fmt.Errorf("%w: the license or installation differs from the activation request", ErrActivationMismatch)
+	}
+	return nil
+}
+
+// Verify verifies the signature of the activation request with the PEM
+// encoded public key of the installation.
+func (r *SignedActivationRequest) Verify(publicKeyPem string) error {
+	data, err := json.Marshal(&r.Request)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(data, r.Signature, r.Request.SignatureOptions, publicKeyPem)
+}
+
+// Verify verifies the signature of the activation token with the PEM encoded
+// public key of the issuer.
+func (t *SignedActivationToken) Verify(publicKeyPem string) error {
+	data, err := json.Marshal(&t.Token)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(data, t.Signature, t.Token.SignatureOptions, publicKeyPem)
+}
+
+// checkActivation returns an error unless the license file does not require
+// activation, or the activation token of the validator, whose signature is
+// verified with pubKey, activates it on the installation. The nonce of the
+// token is not checked here, since the validator does not know the request:
+// ActivationRequest.CheckToken checks it when the installation imports the
+// token, which is the only time the pending request is known.
+func (v *Validator) checkActivation(f *LicenseFile, pubKey *rsa.PublicKey) error {
+	if !f.License.ActivationRequired {
+		return nil
+	}
+	token := v.ActivationToken
+	if token == nil {
+		return ErrActivationRequired
+	}
+	if v.Identity == nil {
+		return ErrNoInstallationIdentity
+	}
+	data, err := json.Marshal(&token.Token)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(data, token.Signature, token.Token.SignatureOptions, pubKey); err != nil {
+		return fmt.Errorf("invalid activation token signature: %w", err)
+	}
+	if token.Token.LicenseID != f.ID() {
+		return fmt.Errorf("%w: the token activates another license", ErrActivationMismatch)
+	}
+	if token.Token.InstallationID != v.Identity.Fingerprint {
+		return fmt.Errorf("%w: the token activates another installation", ErrActivationMismatch)
+	}
+	return nil
+}
+
+// ReadActivationRequest reads a signed activation request from a file.
+func ReadActivationRequest(path string) (*SignedActivationRequest, error) {
+	request := &SignedActivationRequest{}
+	if err := readJSONFile(path, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// WriteActivationRequest writes a signed activation request to a file.
+func WriteActivationRequest(path string, request *SignedActivationRequest) error {
+	return writeJSONFile(path, request)
+}
+
+// ReadActivationToken reads a signed activation token from a file.
+func ReadActivationToken(path string) (*SignedActivationToken, error) {
+	token := &SignedActivationToken{}
+	if err := readJSONFile(path, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// WriteActivationToken writes a signed activation token to a file.
+func WriteActivationToken(path string, token *SignedActivationToken) error {
+	return writeJSONFile(path, token)
+}
+
+// readJSONFile decodes the JSON content of the file into v.
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("cannot decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSONFile writes the indented JSON encoding of v to the file.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,164 @@
+package licensing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivation(t *testing.T) {
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := NewIssuer(catalog, key, NewMemoryStore())
+	file, err := issuer.Issue(context.Background(), &LicenseSpec{
+		Issuer:             "Sensu, Inc.",
+		AccountName:        "Acme Corp.",
+		AccountID:          573,
+		Plan:               "enterprise",
+		ActivationRequired: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := &InstallationIdentity{ClusterID: "cluster-a", Fingerprint: "install-1"}
+
+	validator := &Validator{PublicKey: testPublicKey, Identity: identity}
+	assert.ErrorIs(t, validator.Validate(file), ErrActivationRequired)
+
+	// the installation writes a signed request to a file
+	dir := t.TempDir()
+	request, err := NewActivationRequest(file, identity.Fingerprint, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedRequest, err := request.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requestPath := filepath.Join(dir, "activation-request.json")
+	if err := WriteActivationRequest(requestPath, signedRequest); err != nil {
+		t.Fatal(err)
+	}
+
+	// the issuer answers with a signed token written to a file
+	readRequest, err := ReadActivationRequest(requestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := issuer.Activate(context.Background(), readRequest, testPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenPath := filepath.Join(dir, "activation-token.json")
+	if err := WriteActivationToken(tokenPath, token); err != nil {
+		t.Fatal(err)
+	}
+
+	// the installation checks the token and validates the license with it
+	readToken, err := ReadActivationToken(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, request.CheckToken(readToken, testPublicKey))
+	validator = &Validator{PublicKey: testPublicKey, Identity: identity, ActivationToken: readToken}
+	assert.NoError(t, validator.Validate(file))
+
+	other := &ActivationRequest{Nonce: "other", LicenseID: request.LicenseID, InstallationID: request.InstallationID}
+	assert.ErrorIs(t, other.CheckToken(readToken, testPublicKey), ErrActivationMismatch)
+
+	validator = &Validator{
+		PublicKey:       testPublicKey,
+		Identity:        &InstallationIdentity{ClusterID: "cluster-a", Fingerprint: "install-2"},
+		ActivationToken: readToken,
+	}
+	assert.ErrorIs(t, validator.Validate(file), ErrActivationMismatch)
+
+	tampered := *readToken
+	tampered.Token.InstallationID = "install-2"
+	validator.ActivationToken = &tampered
+	assert.Error(t, validator.Validate(file))
+
+	// licenses unknown to the issuer cannot be activated
+	request, err = NewActivationRequest(signedMockLicenseFile(t, "Acme Corp.", now), identity.Fingerprint, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedRequest, err = request.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = issuer.Activate(context.Background(), signedRequest, testPublicKey)
+	assert.ErrorIs(t, err, ErrUnknownLicense)
+}
+
+func TestActivationRefused(t *testing.T) {
+	ctx := context.Background()
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := NewIssuer(catalog, key, NewMemoryStore())
+	issue := func(t *testing.T, spec *LicenseSpec) *LicenseFile {
+		t.Helper()
+		spec.Issuer, spec.AccountName, spec.Plan = "Sensu, Inc.", "Acme Corp.", "enterprise"
+		file, err := issuer.Issue(ctx, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	activate := func(file *LicenseFile, installationID string, signer *rsa.PrivateKey, publicKey string) error {
+		request, err := NewActivationRequest(file, installationID, now)
+		if err != nil {
+			return err
+		}
+		signed, err := request.Sign(signer)
+		if err != nil {
+			return err
+		}
+		_, err = issuer.Activate(ctx, signed, publicKey)
+		return err
+	}
+
+	file := issue(t, &LicenseSpec{})
+	assert.ErrorIs(t, activate(file, "install-1", key, testPublicKey), ErrActivationRefused, "the license does not require activation")
+
+	file = issue(t, &LicenseSpec{ActivationRequired: true, Binding: &Binding{Fingerprints: []string{"install-1"}}})
+	assert.ErrorIs(t, activate(file, "install-2", key, testPublicKey), ErrActivationRefused, "the license is bound to another installation")
+
+	file = issue(t, &LicenseSpec{ActivationRequired: true})
+	assert.NoError(t, activate(file, "install-1", key, testPublicKey))
+	assert.NoError(t, activate(file, "install-1", key, testPublicKey), "installations can be activated again")
+	assert.ErrorIs(t, activate(file, "install-2", key, testPublicKey), ErrActivationRefused, "the activation limit is reached")
+
+	// installations keep the key of their first activation
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&otherKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPublicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.ErrorIs(t, activate(file, "install-1", otherKey, otherPublicKey), ErrActivationRefused)
+
+	issuer.MaxActivations = 2
+	assert.NoError(t, activate(file, "install-2", otherKey, otherPublicKey))
+}
@@ -0,0 +1,223 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrAccountMismatch is returned when an add-on license belongs to another
+	// account than the base license.
+	ErrAccountMismatch = errors.New("add-on license belongs to another account")
+	// ErrNotAddOn is returned when a license other than an add-on is combined
+	// with a base license.
+	ErrNotAddOn = errors.New("license is not an add-on")
+
+	errBaseAddOn = errors.New("base license must not be an add-on")
+)
+
+// Entitlements are the effective entitlements of a base license combined with
+// its add-on licenses.
+type Entitlements struct {
+	// AccountName is the name of the customer account.
+	AccountName string `json:"accountName"`
+	// AccountID is the ID of the customer account.
+	AccountID uint64 `json:"accountID"`
+	// ValidUntil is the time at which the base license, and therefore every
+	// entitlement, expires.
+	ValidUntil Timestamp `json:"validUntil"`
+	// Features are the enabled features, along with the time at which each of
+	// them expires.
+	Features map[string]Timestamp `json:"features"`
+	// EntityLimit is the limit of the total number of entities allowed, or
+	// zero when unlimited.
+	EntityLimit int `json:"entityLimit,omitempty"`
+	// EntityClassLimits is the limit of entities per entity class.
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty"`
+	// NamespaceLimits are the entity limits of namespaces, from the base
+	// license.
+	NamespaceLimits map[string]NamespaceLimit `json:"namespaceLimits,omitempty"`
+	// OveragePolicy is the overage policy of the base license.
+	OveragePolicy *OveragePolicy `json:"overagePolicy,omitempty"`
+}
+
+// HasFeature returns whether the feature is enabled at the given time.
+func (e *Entitlements) HasFeature(feature string, at time.Time) bool {
+	expiry, ok := e.Features[feature]
+	if !ok {
+		expiry, ok = e.Features[AllFeatures]
+	}
+	return ok && !at.After(time.Time(expiry))
+}
+
+// ValidateAddOn returns an error unless the add-on license file can be
+// combined with the base license file.
+func ValidateAddOn(base, addOn *LicenseFile) error {
+	if base.License.AddOn {
+		return errBaseAddOn
+	}
+	if !addOn.License.AddOn {
+		return ErrNotAddOn
+	}
+	if addOn.License.AccountID != base.License.AccountID {
+		return fmt.Errorf("%w: %d, expected %d", ErrAccountMismatch, addOn.License.AccountID, base.License.AccountID)
+	}
+	return nil
+}
+
+// MergeLicenses combines the base license file with its add-on license files
+// into the entitlements effective at the given time. Add-ons that have expired
+// at that time are ignored. Entity limits are summed, except for the limits
+// the base license leaves unlimited, whether the total limit or the limits of
+// entity classes. Features are unioned, and
+// expire when the first license enabling them expires, but no later than the
+// base license.
+func MergeLicenses(at time.Time, base *LicenseFile, addOns ...*LicenseFile) (*Entitlements, error) {
+	if base.License.AddOn {
+		return nil, errBaseAddOn
+	}
+	baseExpiry := base.License.ValidUntil
+	entitlements := &Entitlements{
+		AccountName:       base.License.AccountName,
+		AccountID:         base.License.AccountID,
+		ValidUntil:        baseExpiry,
+		Features:          make(map[string]Timestamp),
+		EntityLimit:       base.License.EntityLimit,
+		EntityClassLimits: make(map[string]int, len(base.License.EntityClassLimits)),
+		NamespaceLimits:   base.License.NamespaceLimits,
+		OveragePolicy:     base.License.OveragePolicy,
+	}
+	for entityClass, limit := range base.License.EntityClassLimits {
+		entitlements.EntityClassLimits[entityClass] = limit
+	}
+	for _, feature := range base.License.Features {
+		entitlements.Features[feature] = baseExpiry
+	}
+
+	for _, addOn := range addOns {
+		if err := ValidateAddOn(base, addOn); err != nil {
+			return nil, err
+		}
+		if at.After(time.Time(addOn.License.ValidUntil)) {
+			continue
+		}
+
+		if entitlements.EntityLimit != 0 {
+			entitlements.EntityLimit += addOn.License.EntityLimit
+		}
+		for entityClass, limit := range addOn.License.EntityClassLimits {
+			if _, ok := entitlements.EntityClassLimits[entityClass]; ok {
+				entitlements.EntityClassLimits[entityClass] += limit
+			}
+		}
+
+		expiry := addOn.License.ValidUntil
+		if time.Time(expiry).After(time.Time(baseExpiry)) {
+			expiry = baseExpiry
+		}
+		for _, feature := range addOn.License.Features {
+			if current, ok := entitlements.Features[feature]; !ok || time.Time(expiry).Before(time.Time(current)) {
+				entitlements.Features[feature] = expiry
+			}
+		}
+	}
+	return entitlements, nil
+}
+
+// GetAddOnLicenseFiles returns the add-on license files stored at AddOnsKey().
+func GetAddOnLicenseFiles(ctx context.Context, store Store) ([]*LicenseFile, error) {
+	entry, err := store.Get(ctx, AddOnsKey())
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []*LicenseFile
+	if err := json.Unmarshal(entry.Value, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PutAddOnLicenseFiles stores the add-on license files at AddOnsKey(). License
+// files with a namespace are refused with ErrNamespaced.
+func PutAddOnLicenseFiles(ctx context.Context, store Store, files []*LicenseFile) error {
+	for _, file := range files {
+		if err := file.TrySetNamespace(file.ObjectMeta.Namespace); err != nil {
+			return err
+		}
+	}
+	value, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	_, err = store.Put(ctx, AddOnsKey(), value)
+	return err
+}
+
+// LoadEntitlements loads the base license file and its add-on license files
+// from the store, validates them with the given validator, or with the default
+// one when nil, and merges them into the entitlements effective at the given
+// time. An error is returned if any add-on license file is invalid.
+func LoadEntitlements(ctx context.Context, store Store, validator *Validator, at time.Time) (*Entitlements, error) {
+	if validator == nil {
+		validator = defaultValidator
+	}
+	base, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(base); err != nil {
+		return nil, err
+	}
+	entitlements, _, invalid, err := loadAddOns(ctx, store, validator, base, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range invalid {
+		return nil, err
+	}
+	return entitlements, nil
+}
+
+// loadAddOns loads the add-on license files from the store, validates them
+// with the validator and merges them with the validated base license file
+// into the entitlements effective at the given time. The add-ons that have
+// not expired are returned along with the entitlements, and the invalid ones,
+// which are left out of the entitlements, are returned with their validation
+// errors by ID.
+func loadAddOns(ctx context.Context, store Store, validator *Validator, base *LicenseFile, at time.Time) (*Entitlements, []*LicenseFile, map[string]error, error) {
+	addOns, err := GetAddOnLicenseFiles(ctx, store)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	valid := make([]*LicenseFile, 0, len(addOns))
+	var invalid map[string]error
+	for _, addOn := range addOns {
+		err := validator.Validate(addOn)
+		if errors.Is(err, ErrExpired) || (err == nil && at.After(time.Time(addOn.License.ValidUntil))) {
+			// expired add-ons no longer entitle to anything
+			continue
+		}
+		if err == nil {
+			err = ValidateAddOn(base, addOn)
+		}
+		if err != nil {
+			if invalid == nil {
+				invalid = make(map[string]error)
+			}
+			invalid[addOn.ID()] = fmt.Errorf("invalid add-on license %s: %w", addOn.ID(), err)
+			continue
+		}
+		valid = append(valid, addOn)
+	}
+	entitlements, err := MergeLicenses(at, base, valid...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return entitlements, valid, invalid, nil
+}
@@ -0,0 +1,119 @@
+package licensing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLicenses(t *testing.T) {
+	base := &LicenseFile{License: License{
+		AccountID:         573,
+		ValidUntil:        Timestamp(now.Add(48 * time.Hour)),
+		Features:          FeatureList{"rbac"},
+		EntityLimit:       100,
+		EntityClassLimits: map[string]int{"agent": 50},
+	}}
+	agents := &LicenseFile{License: License{
+		AccountID:         573,
+		AddOn:             true,
+		ValidUntil:        Timestamp(now.Add(24 * time.Hour)),
+		Features:          FeatureList{"ldap"},
+		EntityLimit:       20,
+		EntityClassLimits: map[string]int{"agent": 20, "proxy": 5},
+	}}
+	longer := &LicenseFile{License: License{
+		AccountID:  573,
+		AddOn:      true,
+		ValidUntil: Timestamp(now.Add(96 * time.Hour)),
+		Features:   FeatureList{"ldap", "federation"},
+	}}
+	expired := &LicenseFile{License: License{
+		AccountID:   573,
+		AddOn:       true,
+		ValidUntil:  Timestamp(now.Add(-time.Hour)),
+		Features:    FeatureList{"sso"},
+		EntityLimit: 1000,
+	}}
+
+	entitlements, err := MergeLicenses(now, base, agents, longer, expired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 120, entitlements.EntityLimit)
+	assert.Equal(t, map[string]int{"agent": 70}, entitlements.EntityClassLimits, "entity classes unlimited by the base license should stay unlimited")
+	assert.Equal(t, map[string]Timestamp{
+		"rbac":       base.License.ValidUntil,
+		"ldap":       agents.License.ValidUntil,
+		"federation": base.License.ValidUntil,
+	}, entitlements.Features, "features should expire with the first license enabling them, and no later than the base license")
+	assert.True(t, entitlements.HasFeature("ldap", now))
+	assert.False(t, entitlements.HasFeature("sso", now))
+	assert.False(t, entitlements.HasFeature("rbac", now.Add(72*time.Hour)))
+
+	entitlements, err = MergeLicenses(now, base, agents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, agents.License.ValidUntil, entitlements.Features["ldap"])
+
+	quota := NewEntitlementsQuota(entitlements, map[string]int{"agent": 60})
+	assert.True(t, quota.Admit("agent").Allowed)
+
+	unlimited := &LicenseFile{License: License{AccountID: 573, ValidUntil: base.License.ValidUntil}}
+	entitlements, err = MergeLicenses(now, unlimited, agents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, entitlements.EntityLimit)
+	assert.Empty(t, entitlements.EntityClassLimits)
+
+	other := &LicenseFile{License: License{AccountID: 42, AddOn: true}}
+	_, err = MergeLicenses(now, base, other)
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = MergeLicenses(now, base, base)
+	assert.ErrorIs(t, err, ErrNotAddOn)
+	_, err = MergeLicenses(now, agents)
+	assert.Error(t, err, "an add-on cannot be a base license")
+}
+
+func TestLoadEntitlements(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	validator := &Validator{PublicKey: testPublicKey}
+
+	base := testMockLicenseFile()
+	base.License.ValidUntil = Timestamp(now.Add(48 * time.Hour))
+	base.License.EntityLimit = 100
+	if err := SignLicenseFile(base, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutLicenseFile(ctx, store, base); err != nil {
+		t.Fatal(err)
+	}
+	addOn := testMockLicenseFile()
+	addOn.License.AddOn = true
+	addOn.License.ValidUntil = Timestamp(now.Add(24 * time.Hour))
+	addOn.License.EntityLimit = 10
+	if err := SignLicenseFile(addOn, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutAddOnLicenseFiles(ctx, store, []*LicenseFile{addOn}); err != nil {
+		t.Fatal(err)
+	}
+
+	entitlements, err := LoadEntitlements(ctx, store, validator, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 110, entitlements.EntityLimit)
+
+	addOn.License.EntityLimit = 1000
+	if err := PutAddOnLicenseFiles(ctx, store, []*LicenseFile{addOn}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadEntitlements(ctx, store, validator, now)
+	assert.Error(t, err, "tampered add-ons should be refused")
+}
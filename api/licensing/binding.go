@@ -0,0 +1,78 @@
+package licensing
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrWrongCluster is returned when a license bound to a cluster is applied
+	// to another one.
+	ErrWrongCluster = errors.New("license is bound to another cluster")
+	// ErrWrongInstallation is returned when a license bound to installation
+	// fingerprints is applied to an installation matching none of them.
+	ErrWrongInstallation = errors.New("license is bound to other installations")
+	// ErrNoInstallationIdentity is returned when a bound license is validated
+	// without an installation identity.
+	ErrNoInstallationIdentity = errors.New("license is bound, but the installation identity is unknown")
+)
+
+// Binding restricts the installations a license can be applied to. A license
+// with a binding is only valid on the cluster with the given ID, if any, and
+// on the installations with one of the given fingerprints, if any.
+type Binding struct {
+	// ClusterID is the ID of the cluster the license is bound to.
+	ClusterID string `json:"clusterID,omitempty" yaml:"clusterID,omitempty"`
+	// Fingerprints are the fingerprints of the installations the license is
+	// bound to. Fingerprints must not depend on the hardware of the
+	// installation, so that they survive the replacement of its nodes.
+	Fingerprints []string `json:"fingerprints,omitempty" yaml:"fingerprints,omitempty"`
+}
+
+// InstallationIdentity identifies the installation a license is applied to.
+type InstallationIdentity struct {
+	// ClusterID is the ID of the cluster.
+	ClusterID string `json:"clusterID"`
+	// Fingerprint is the fingerprint of the installation.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Validate returns an error if the binding does not restrict installations.
+// A nil binding is valid.
+func (b *Binding) Validate() error {
+	if b == nil {
+		return nil
+	}
+	if b.ClusterID == "" && len(b.Fingerprints) == 0 {
+		return errors.New("license binding requires a cluster ID or fingerprints")
+	}
+	for _, fingerprint := range b.Fingerprints {
+		if fingerprint == "" {
+			return errors.New("license binding fingerprints must not be empty")
+		}
+	}
+	return nil
+}
+
+// Check returns an error if the installation with the given identity does not
+// match the binding. A nil binding matches any installation.
+func (b *Binding) Check(identity *InstallationIdentity) error {
+	if b == nil {
+		return nil
+	}
+	if identity == nil {
+		return ErrNoInstallationIdentity
+	}
+	if b.ClusterID != "" && b.ClusterID != identity.ClusterID {
+		return fmt.Errorf("%w: bound to cluster %q, applied to cluster %q", ErrWrongCluster, b.ClusterID, identity.ClusterID)
+	}
+	if len(b.Fingerprints) == 0 {
+		return nil
+	}
+	for _, fingerprint := range b.Fingerprints {
+		if fingerprint == identity.Fingerprint {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: installation fingerprint %q is not bound", ErrWrongInstallation, identity.Fingerprint)
+}
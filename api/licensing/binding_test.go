@@ -0,0 +1,70 @@
+package licensing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinding(t *testing.T) {
+	file := testMockLicenseFile()
+	file.License.ValidUntil = Timestamp(now.Add(time.Hour))
+	file.License.Binding = &Binding{
+		ClusterID:    "cluster-a",
+		Fingerprints: []string{"install-1", "install-2"},
+	}
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		identity *InstallationIdentity
+		wantErr  error
+	}{
+		{
+			name:     "matching installation",
+			identity: &InstallationIdentity{ClusterID: "cluster-a", Fingerprint: "install-2"},
+		},
+		{
+			name:     "wrong cluster",
+			identity: &InstallationIdentity{ClusterID: "cluster-b", Fingerprint: "install-1"},
+			wantErr:  ErrWrongCluster,
+		},
+		{
+			name:     "wrong installation",
+			identity: &InstallationIdentity{ClusterID: "cluster-a", Fingerprint: "install-3"},
+			wantErr:  ErrWrongInstallation,
+		},
+		{
+			name:    "unknown installation",
+			wantErr: ErrNoInstallationIdentity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &Validator{PublicKey: testPublicKey, Identity: tt.identity}
+			err := validator.Validate(file)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	// unbound licenses can be applied to any installation
+	unbound := signedMockLicenseFile(t, "Acme Corp.", now.Add(time.Hour))
+	validator := &Validator{PublicKey: testPublicKey, Identity: &InstallationIdentity{ClusterID: "cluster-b"}}
+	assert.NoError(t, validator.Validate(unbound))
+}
+
+func TestBindingValidate(t *testing.T) {
+	var binding *Binding
+	assert.NoError(t, binding.Validate())
+	assert.NoError(t, (&Binding{ClusterID: "cluster-a"}).Validate())
+	assert.NoError(t, (&Binding{Fingerprints: []string{"install-1"}}).Validate())
+	assert.Error(t, (&Binding{}).Validate())
+	assert.Error(t, (&Binding{Fingerprints: []string{""}}).Validate())
+}
@@ -0,0 +1,88 @@
+package licensing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	corev2 "github.com/sensu/core/v2"
+)
+
+var (
+	entityClassesMu sync.RWMutex
+	// entityClasses are the entity classes that licenses may limit.
+	entityClasses = map[string]struct{}{
+		corev2.EntityAgentClass: {},
+		corev2.EntityProxyClass: {},
+	}
+	// builtinEntityClasses are the entity classes that licenses may always
+	// limit.
+	builtinEntityClasses = map[string]struct{}{
+		corev2.EntityAgentClass: {},
+		corev2.EntityProxyClass: {},
+	}
+	// entityClassesGeneration is incremented whenever the registered entity
+	// classes change, so that cached validation results can be invalidated.
+	entityClassesGeneration uint64
+)
+
+// RegisterEntityClass allows licenses to limit entities of the given classes,
+// in addition to the agent and proxy entity classes, e.g.
+// corev2.EntityBackendClass or corev2.EntityServiceClass. The registry is
+// shared by the whole process, Validator.EntityClasses configures a single
+// validator instead.
+func RegisterEntityClass(classes ...string) {
+	entityClassesMu.Lock()
+	defer entityClassesMu.Unlock()
+	for _, entityClass := range classes {
+		if _, ok := entityClasses[entityClass]; !ok {
+			entityClasses[entityClass] = struct{}{}
+			entityClassesGeneration++
+		}
+	}
+}
+
+// UnregisterEntityClass disallows licenses to limit entities of the given
+// class. The agent and proxy entity classes cannot be unregistered.
+func UnregisterEntityClass(entityClass string) error {
+	if _, ok := builtinEntityClasses[entityClass]; ok {
+		return fmt.Errorf("cannot unregister the built-in entity class %s", entityClass)
+	}
+	entityClassesMu.Lock()
+	defer entityClassesMu.Unlock()
+	if _, ok := entityClasses[entityClass]; ok {
+		delete(entityClasses, entityClass)
+		entityClassesGeneration++
+	}
+	return nil
+}
+
+// IsEntityClassRegistered returns whether licenses may limit entities of the
+// given class.
+func IsEntityClassRegistered(entityClass string) bool {
+	entityClassesMu.RLock()
+	defer entityClassesMu.RUnlock()
+	_, ok := entityClasses[entityClass]
+	return ok
+}
+
+// RegisteredEntityClasses returns the entity classes that licenses may limit,
+// sorted by name.
+func RegisteredEntityClasses() []string {
+	entityClassesMu.RLock()
+	defer entityClassesMu.RUnlock()
+	classes := make([]string, 0, len(entityClasses))
+	for entityClass := range entityClasses {
+		classes = append(classes, entityClass)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// registeredEntityClassesGeneration returns the generation of the registered
+// entity classes.
+func registeredEntityClassesGeneration() uint64 {
+	entityClassesMu.RLock()
+	defer entityClassesMu.RUnlock()
+	return entityClassesGeneration
+}
@@ -0,0 +1,61 @@
+package licensing
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/core/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterEntityClass(t *testing.T) {
+	assert.Equal(t, []string{"agent", "proxy"}, RegisteredEntityClasses())
+
+	file := testMockLicenseFile()
+	file.License.EntityClassLimits = map[string]int{
+		corev2.EntityAgentClass:   10,
+		corev2.EntityBackendClass: 3,
+	}
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	validator := &Validator{PublicKey: testPublicKey}
+	assert.Error(t, file.ValidateEntityClasses())
+	assert.Error(t, validator.Validate(file))
+
+	RegisterEntityClass(corev2.EntityBackendClass)
+	defer UnregisterEntityClass(corev2.EntityBackendClass)
+	assert.True(t, IsEntityClassRegistered(corev2.EntityBackendClass))
+	assert.Equal(t, []string{"agent", "backend", "proxy"}, RegisteredEntityClasses())
+	assert.NoError(t, file.ValidateEntityClasses())
+	assert.NoError(t, validator.Validate(file), "cached results should be invalidated by new entity classes")
+
+	assert.NoError(t, UnregisterEntityClass(corev2.EntityBackendClass))
+	assert.False(t, IsEntityClassRegistered(corev2.EntityBackendClass))
+	assert.Error(t, validator.Validate(file))
+
+	// the built-in entity classes cannot be unregistered
+	assert.Error(t, UnregisterEntityClass(corev2.EntityAgentClass))
+	assert.Error(t, UnregisterEntityClass(corev2.EntityProxyClass))
+	assert.Equal(t, []string{"agent", "proxy"}, RegisteredEntityClasses())
+}
+
+func TestValidatorEntityClasses(t *testing.T) {
+	file := testMockLicenseFile()
+	file.License.EntityClassLimits = map[string]int{
+		corev2.EntityAgentClass:   10,
+		corev2.EntityBackendClass: 3,
+	}
+	file.License.NamespaceLimits = map[string]NamespaceLimit{
+		"default": {EntityClassLimits: map[string]int{corev2.EntityBackendClass: 1}},
+	}
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := &Validator{PublicKey: testPublicKey, EntityClasses: []string{corev2.EntityBackendClass}}
+	assert.NoError(t, validator.Validate(file))
+	assert.False(t, IsEntityClassRegistered(corev2.EntityBackendClass), "the registry should not be modified")
+
+	validator = &Validator{PublicKey: testPublicKey, EntityClasses: []string{}}
+	assert.Error(t, validator.Validate(file))
+}
@@ -0,0 +1,181 @@
+package licensing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// EtcdStore is a Store backed by etcd. The revision of an entry is its etcd
+// modification revision.
+type EtcdStore struct {
+	kv      etcdserverpb.KVClient
+	watcher etcdserverpb.WatchClient
+}
+
+// NewEtcdStore creates an EtcdStore using the given etcd clients, which may be
+// created from the gRPC connection of an etcd client with
+// etcdserverpb.NewKVClient and etcdserverpb.NewWatchClient.
+func NewEtcdStore(kv etcdserverpb.KVClient, watcher etcdserverpb.WatchClient) *EtcdStore {
+	return &EtcdStore{
+		kv:      kv,
+		watcher: watcher,
+	}
+}
+
+// Get implements the Store interface.
+func (s *EtcdStore) Get(ctx context.Context, key string) (*Entry, error) {
+	resp, err := s.kv.Range(ctx, &etcdserverpb.RangeRequest{Key: []byte(key)})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, ErrNotFound
+	}
+	return etcdEntry(resp.Kvs[0]), nil
+}
+
+// Put implements the Store interface.
+func (s *EtcdStore) Put(ctx context.Context, key string, value []byte) (int64, error) {
+	resp, err := s.kv.Put(ctx, &etcdserverpb.PutRequest{Key: []byte(key), Value: value})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+// CompareAndSwap implements the Store interface.
+func (s *EtcdStore) CompareAndSwap(ctx context.Context, key string, value []byte, revision int64) (int64, error) {
+	resp, err := s.kv.Txn(ctx, &etcdserverpb.TxnRequest{
+		Compare: []*etcdserverpb.Compare{
+			{
+				Key:         []byte(key),
+				Target:      etcdserverpb.Compare_MOD,
+				Result:      etcdserverpb.Compare_EQUAL,
+				TargetUnion: &etcdserverpb.Compare_ModRevision{ModRevision: revision},
+			},
+		},
+		Success: []*etcdserverpb.RequestOp{
+			{
+				Request: &etcdserverpb.RequestOp_RequestPut{
+					RequestPut: &etcdserverpb.PutRequest{Key: []byte(key), Value: value},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Succeeded {
+		return 0, ErrRevisionMismatch
+	}
+	return resp.Header.Revision, nil
+}
+
+// Delete implements the Store interface.
+func (s *EtcdStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.kv.DeleteRange(ctx, &etcdserverpb.DeleteRangeRequest{Key: []byte(key)})
+	if err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List implements the Store interface.
+func (s *EtcdStore) List(ctx context.Context, prefix string) ([]*Entry, error) {
+	resp, err := s.kv.Range(ctx, &etcdserverpb.RangeRequest{
+		Key:        []byte(prefix),
+		RangeEnd:   []byte(prefixRangeEnd(prefix)),
+		SortOrder:  etcdserverpb.RangeRequest_ASCEND,
+		SortTarget: etcdserverpb.RangeRequest_KEY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*Entry, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		entries = append(entries, etcdEntry(kv))
+	}
+	return entries, nil
+}
+
+// Watch implements the Store interface. It returns once etcd has created the
+// watch, so that no change made afterwards is missed. If the watch cannot be
+// created, or if the watch stream fails, a WatchError event is reported before
+// the channel is closed.
+func (s *EtcdStore) Watch(ctx context.Context, key string) <-chan WatchEvent {
+	queue, ch := newEventQueue[WatchEvent](ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	fail := func(err error) {
+		cancel()
+		queue.push(WatchEvent{Type: WatchError, Err: err})
+		queue.close()
+	}
+
+	request := &etcdserverpb.WatchCreateRequest{Key: []byte(key)}
+	if strings.HasSuffix(key, keySeparator) {
+		request.RangeEnd = []byte(prefixRangeEnd(key))
+	}
+
+	stream, err := s.watcher.Watch(ctx)
+	if err == nil {
+		err = stream.Send(&etcdserverpb.WatchRequest{
+			RequestUnion: &etcdserverpb.WatchRequest_CreateRequest{CreateRequest: request},
+		})
+	}
+	if err != nil {
+		fail(fmt.Errorf("cannot watch %s: %w", key, err))
+		return ch
+	}
+	// changes are only reported once the watch is created
+	resp, err := stream.Recv()
+	if err == nil && (resp.Canceled || !resp.Created) {
+		err = fmt.Errorf("watch canceled: %s", resp.CancelReason)
+	}
+	if err != nil {
+		fail(fmt.Errorf("cannot watch %s: %w", key, err))
+		return ch
+	}
+
+	go func() {
+		defer cancel()
+		for {
+			resp, err := stream.Recv()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fail(fmt.Errorf("watch of %s failed: %w", key, err))
+				return
+			}
+			if resp.Canceled {
+				fail(fmt.Errorf("watch of %s canceled: %s", key, resp.CancelReason))
+				return
+			}
+			for _, event := range resp.Events {
+				switch event.Type {
+				case mvccpb.PUT:
+					queue.push(WatchEvent{Type: WatchPut, Entry: etcdEntry(event.Kv)})
+				case mvccpb.DELETE:
+					queue.push(WatchEvent{Type: WatchDelete, Entry: etcdEntry(event.Kv)})
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// etcdEntry converts an etcd key-value pair to an Entry.
+func etcdEntry(kv *mvccpb.KeyValue) *Entry {
+	return &Entry{
+		Key:      string(kv.Key),
+		Value:    kv.Value,
+		Revision: kv.ModRevision,
+	}
+}
@@ -0,0 +1,104 @@
+package licensing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/embed"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// freeURL returns an http URL on a local port that is free to listen on.
+func freeURL(t *testing.T) url.URL {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+// newTestEtcdStore starts an embedded etcd server for the duration of the
+// test, and returns an EtcdStore connected to it.
+func newTestEtcdStore(t *testing.T) *EtcdStore {
+	t.Helper()
+	conn := startTestEtcd(t)
+	return NewEtcdStore(etcdserverpb.NewKVClient(conn), etcdserverpb.NewWatchClient(conn))
+}
+
+// startTestEtcd starts an embedded etcd server for the duration of the test,
+// and returns a connection to it.
+func startTestEtcd(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, peerURL := freeURL(t), freeURL(t)
+	cfg.LCUrls, cfg.ACUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	cfg.LPUrls, cfg.APUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	cfg.InitialCluster = fmt.Sprintf("%s=%s", cfg.Name, peerURL.String())
+
+	etcd, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(etcd.Close)
+	select {
+	case <-etcd.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for etcd to start")
+	}
+
+	conn, err := grpc.Dial(clientURL.Host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestEtcdStoreRequests(t *testing.T) {
+	ctx := context.Background()
+	conn := startTestEtcd(t)
+	kv := etcdserverpb.NewKVClient(conn)
+	store := NewEtcdStore(kv, etcdserverpb.NewWatchClient(conn))
+
+	if err := PutLicenseFile(ctx, store, testMockLicenseFile()); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := kv.Range(ctx, &etcdserverpb.RangeRequest{Key: []byte(LicenseKey())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Kvs, 1, "the license file should be stored at LicenseKey()")
+}
+
+func TestEtcdStoreWatchError(t *testing.T) {
+	conn := startTestEtcd(t)
+	store := NewEtcdStore(etcdserverpb.NewKVClient(conn), etcdserverpb.NewWatchClient(conn))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := store.Watch(ctx, LicenseKey())
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	event := nextEvent(t, events)
+	assert.Equal(t, WatchError, event.Type)
+	assert.Error(t, event.Err)
+	_, ok := <-events
+	assert.False(t, ok, "the channel should be closed after the error")
+
+	// watches that cannot be created fail the same way
+	event = nextEvent(t, store.Watch(ctx, LicenseKey()))
+	assert.Equal(t, WatchError, event.Type)
+	assert.Error(t, event.Err)
+}
@@ -0,0 +1,322 @@
+package licensing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is the default interval at which a FileStore checks
+// the file system for changes to watched keys.
+const DefaultPollInterval = time.Second
+
+// revisionFileName is the name of the file, under the root directory of a
+// FileStore, holding the last revision it assigned.
+const revisionFileName = ".revision"
+
+// FileStore is a Store keeping each entry in a file under a root directory,
+// at the path given by its key. The first line of the file holds the revision
+// of the entry, followed by its value. Revisions are assigned from a counter
+// persisted under the root directory, so that they keep increasing across
+// restarts.
+type FileStore struct {
+	// PollInterval is the interval at which watched keys are checked for
+	// changes.
+	PollInterval time.Duration
+
+	root string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a FileStore rooted at the given directory.
+func NewFileStore(root string) *FileStore {
+	return &FileStore{
+		PollInterval: DefaultPollInterval,
+		root:         root,
+	}
+}
+
+// Get implements the Store interface.
+func (s *FileStore) Get(ctx context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(key)
+}
+
+func (s *FileStore) get(key string) (*Entry, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	revision, value, err := decodeFileEntry(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry at %s: %w", key, err)
+	}
+	return &Entry{Key: key, Value: value, Revision: revision}, nil
+}
+
+// Put implements the Store interface.
+func (s *FileStore) Put(ctx context.Context, key string, value []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(key, value)
+}
+
+// CompareAndSwap implements the Store interface. The swap is only atomic with
+// regard to the other operations of the same FileStore.
+func (s *FileStore) CompareAndSwap(ctx context.Context, key string, value []byte, revision int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var current int64
+	entry, err := s.get(key)
+	if err == nil {
+		current = entry.Revision
+	} else if !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
+	if current != revision {
+		return 0, ErrRevisionMismatch
+	}
+	return s.put(key, value)
+}
+
+func (s *FileStore) put(key string, value []byte) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	revision, err := s.nextRevision()
+	if err != nil {
+		return 0, err
+	}
+	data := append([]byte(strconv.FormatInt(revision, 10)+"\n"), value...)
+	if err := writeFileAtomic(path, data); err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
+
+// nextRevision increments the persisted revision counter and returns its new
+// value.
+func (s *FileStore) nextRevision() (int64, error) {
+	path := filepath.Join(s.root, revisionFileName)
+	var revision int64
+	data, err := os.ReadFile(path)
+	if err == nil {
+		revision, err = strconv.ParseInt(string(bytes.TrimSpace(data)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid revision counter: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+	revision++
+	if err := writeFileAtomic(path, []byte(strconv.FormatInt(revision, 10))); err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
+
+// writeFileAtomic writes data to a temporary file first and renames it to
+// path, so that readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// decodeFileEntry splits the content of an entry file into the revision and
+// the value of the entry.
+func decodeFileEntry(data []byte) (int64, []byte, error) {
+	line, value, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return 0, nil, errors.New("missing revision")
+	}
+	revision, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid revision: %w", err)
+	}
+	return revision, value, nil
+}
+
+// Delete implements the Store interface.
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// List implements the Store interface.
+func (s *FileStore) List(ctx context.Context, prefix string) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	root, err := s.path(prefix)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		entry, err := s.get(keySeparator + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// Watch implements the Store interface. Changes are detected by polling the
+// file system every PollInterval.
+func (s *FileStore) Watch(ctx context.Context, key string) <-chan WatchEvent {
+	queue, ch := newEventQueue[WatchEvent](ctx)
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	revisions := s.revisions(key)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			current := s.revisions(key)
+			for k, revision := range current {
+				if revisions[k] == revision {
+					continue
+				}
+				entry, err := s.Get(ctx, k)
+				if err != nil {
+					// the entry was removed since, which is reported below
+					continue
+				}
+				queue.push(WatchEvent{Type: WatchPut, Entry: entry})
+			}
+			for k := range revisions {
+				if _, ok := current[k]; !ok {
+					queue.push(WatchEvent{Type: WatchDelete, Entry: &Entry{Key: k}})
+				}
+			}
+			revisions = current
+		}
+	}()
+	return ch
+}
+
+// revisions returns the revision of every entry watched by a watch on key.
+func (s *FileStore) revisions(key string) map[string]int64 {
+	revisions := make(map[string]int64)
+	root, err := s.path(key)
+	if err != nil {
+		return revisions
+	}
+	if !strings.HasSuffix(key, keySeparator) {
+		if revision, err := readFileRevision(root); err == nil {
+			revisions[key] = revision
+		}
+		return revisions
+	}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		revision, err := readFileRevision(path)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return nil
+		}
+		revisions[keySeparator+filepath.ToSlash(rel)] = revision
+		return nil
+	})
+	return revisions
+}
+
+// readFileRevision reads the revision on the first line of an entry file.
+func readFileRevision(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSuffix(line, "\n"), 10, 64)
+}
+
+// path returns the path of the file holding the entry at key, or
+// ErrInvalidKey if the key escapes the root directory.
+func (s *FileStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, key)
+	}
+	return path, nil
+}
@@ -0,0 +1,152 @@
+package licensing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sensu/core/v3/types"
+)
+
+// maxLicenseFileSize is the maximum size of a license file accepted by a
+// LicenseHandler.
+const maxLicenseFileSize = 1 << 20
+
+// errLicenseFileTooLarge is returned when a license file exceeds
+// maxLicenseFileSize.
+var errLicenseFileTooLarge = fmt.Errorf("license file exceeds %d bytes", maxLicenseFileSize)
+
+// LicenseHandler is an http.Handler serving the license resource at
+// LicenseURI(). GET responds with the wrapped license file, PUT validates and
+// applies a license file through a History and responds with its validation
+// report, and DELETE removes the license file. The History records trial
+// license files in a TrialLedger, so that a second trial is refused.
+type LicenseHandler struct {
+	store     Store
+	validator *Validator
+	history   *History
+}
+
+// NewLicenseHandler creates a LicenseHandler persisting the license in the
+// given store. License files are validated with the given validator, or with
+// the default one when nil.
+func NewLicenseHandler(store Store, validator *Validator) *LicenseHandler {
+	if validator == nil {
+		validator = new(Validator)
+	}
+	return &LicenseHandler{
+		store:     store,
+		validator: validator,
+		history:   NewHistory(store, DefaultHistoryLimit, validator),
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *LicenseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != LicenseURI() {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		file, err := GetLicenseFile(r.Context(), h.store)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, types.WrapResource(file))
+	case http.MethodPut:
+		file, err := decodeLicenseFile(r.Body)
+		if errors.Is(err, errLicenseFileTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := file.TrySetNamespace(file.ObjectMeta.Namespace); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		report := h.validator.Report(file)
+		if report.Valid && file.License.AddOn {
+			// add-ons are combined with the license, not used as one
+			report.Valid = false
+			report.Error = errBaseAddOn.Error()
+		}
+		if !report.Valid {
+			writeJSON(w, http.StatusBadRequest, report)
+			return
+		}
+		if _, err := h.history.Apply(r.Context(), file, requestIdentity(r)); errors.Is(err, ErrTrialUsed) {
+			// each installation can only use a single trial
+			report.Valid = false
+			report.Error = err.Error()
+			writeJSON(w, http.StatusBadRequest, report)
+			return
+		} else if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+	case http.MethodDelete:
+		if err := DeleteLicenseFile(r.Context(), h.store); err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeLicenseFile decodes a license file, either as is or wrapped in a
+// types.Wrapper, or returns errLicenseFileTooLarge if it exceeds
+// maxLicenseFileSize.
+func decodeLicenseFile(r io.Reader) (*LicenseFile, error) {
+	// read one more byte than allowed to detect oversized license files
+	body, err := io.ReadAll(io.LimitReader(r, maxLicenseFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxLicenseFileSize {
+		return nil, errLicenseFileTooLarge
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["spec"]; !ok {
+		file := &LicenseFile{}
+		if err := json.Unmarshal(body, file); err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+
+	return UnmarshalWrappedJSON(body)
+}
+
+// requestIdentity returns the identity recorded in the license history for a
+// request: the basic authentication user if any, or the remote address.
+func requestIdentity(r *http.Request) string {
+	if user, _, ok := r.BasicAuth(); ok {
+		return user
+	}
+	return r.RemoteAddr
+}
+
+// writeStoreError responds with the status code matching a store error.
+func writeStoreError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,130 @@
+package licensing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sensu/core/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func doRequest(h http.Handler, method string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, LicenseURI(), bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLicenseHandler(t *testing.T) {
+	store := NewMemoryStore()
+	handler := NewLicenseHandler(store, &Validator{PublicKey: testPublicKey})
+
+	rec := doRequest(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrapped license files are accepted, as sent by sensuctl create
+	body, err := json.Marshal(types.WrapResource(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	report := &ValidationReport{}
+	if err := json.Unmarshal(rec.Body.Bytes(), report); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, report.Valid)
+	assert.Equal(t, "Acme Corp.", report.AccountName)
+
+	rec = doRequest(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	wrapper := &types.Wrapper{}
+	if err := json.Unmarshal(rec.Body.Bytes(), wrapper); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.License, wrapper.Value.(*LicenseFile).License)
+
+	expired := testMockLicenseFile()
+	expired.License.ValidUntil = Timestamp(now.Add(-time.Hour))
+	if err := SignLicenseFile(expired, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	body, err = json.Marshal(expired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	report = &ValidationReport{}
+	if err := json.Unmarshal(rec.Body.Bytes(), report); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, report.Valid)
+	assert.Equal(t, ErrExpired.Error(), report.Error)
+
+	addOn := testMockLicenseFile()
+	addOn.License.AddOn = true
+	if err := SignLicenseFile(addOn, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	body, err = json.Marshal(addOn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "add-ons cannot be applied as the license")
+
+	stored, err := GetLicenseFile(context.Background(), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.License, stored.License, "invalid licenses should not be stored")
+
+	records, err := NewHistory(store, 0, nil).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, records, 1, "applied licenses should be recorded in the history") {
+		assert.Equal(t, file.License, records[0].LicenseFile.License)
+		assert.Equal(t, "192.0.2.1:1234", records[0].AppliedBy)
+	}
+
+	rec = doRequest(handler, http.MethodDelete, nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = doRequest(handler, http.MethodDelete, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = doRequest(handler, http.MethodPut, bytes.Repeat([]byte(" "), maxLicenseFileSize+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec = doRequest(handler, http.MethodPost, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestLicenseHandlerDefaultValidator(t *testing.T) {
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the test key is not the Sensu signing key
+	store := NewMemoryStore()
+	rec := doRequest(NewLicenseHandler(store, nil), http.MethodPut, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	_, err = GetLicenseFile(context.Background(), store)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
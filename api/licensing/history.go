@@ -0,0 +1,222 @@
+package licensing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultHistoryLimit is the default number of license files kept in the
+// license history.
+const DefaultHistoryLimit = 10
+
+// HistoryRecord is a license file that was applied, along with when and by
+// whom it was applied.
+type HistoryRecord struct {
+	// Revision identifies the application of the license file in the
+	// history. It increases with each license file applied.
+	Revision int64 `json:"revision"`
+	// AppliedAt is the time at which the license file was applied.
+	AppliedAt Timestamp `json:"appliedAt"`
+	// AppliedBy is the identity of who applied the license file.
+	AppliedBy string `json:"appliedBy"`
+	// LicenseFile is the applied license file.
+	LicenseFile *LicenseFile `json:"licenseFile"`
+}
+
+// History applies license files to a store, and keeps a bounded history of
+// the applied license files at LicenseHistoryKey(). License files are
+// recorded in the history before being stored at LicenseKey(), so that an
+// application interrupted in between can be completed with RollForward.
+type History struct {
+	store     Store
+	limit     int
+	validator *Validator
+
+	// now returns the time at which license files are applied.
+	now func() time.Time
+}
+
+// NewHistory creates a History keeping up to limit license files in the given
+// store, or DefaultHistoryLimit when limit is not positive. License files are
+// validated with the given validator before being applied or rolled back to,
+// or with the default one when nil.
+func NewHistory(store Store, limit int, validator *Validator) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	if validator == nil {
+		validator = new(Validator)
+	}
+	return &History{
+		store:     store,
+		limit:     limit,
+		validator: validator,
+		now:       time.Now,
+	}
+}
+
+// Apply validates the license file, records it in the history and stores it
+// as the active license.
+func (h *History) Apply(ctx context.Context, file *LicenseFile, appliedBy string) (*HistoryRecord, error) {
+	if err := h.validator.Validate(file); err != nil {
+		return nil, err
+	}
+	return h.apply(ctx, file, appliedBy)
+}
+
+// apply records the validated license file in the history and stores it as
+// the active license, unless it is an add-on. Trial license files are checked
+// against the TrialLedger of the store, and accepted in it once recorded in
+// the history, so that each installation can only use a single trial.
+// License files with a namespace are refused with ErrNamespaced.
+func (h *History) apply(ctx context.Context, file *LicenseFile, appliedBy string) (*HistoryRecord, error) {
+	if file.License.AddOn {
+		return nil, errBaseAddOn
+	}
+	if err := file.TrySetNamespace(file.ObjectMeta.Namespace); err != nil {
+		return nil, err
+	}
+	ledger := NewTrialLedger(h.store)
+	ledger.now = h.now
+	if file.License.Trial {
+		if err := ledger.Check(ctx, file); err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 0; attempt < maxCompareAndSwapAttempts; attempt++ {
+		records, historyRevision, err := h.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+		record := &HistoryRecord{
+			Revision:    1,
+			AppliedAt:   Timestamp(h.now().Truncate(time.Second)),
+			AppliedBy:   appliedBy,
+			LicenseFile: file,
+		}
+		if len(records) > 0 {
+			record.Revision = records[len(records)-1].Revision + 1
+		}
+		records = append(records, record)
+		if len(records) > h.limit {
+			records = records[len(records)-h.limit:]
+		}
+		value, err := json.Marshal(records)
+		if err != nil {
+			return nil, err
+		}
+		_, err = h.store.CompareAndSwap(ctx, LicenseHistoryKey(), value, historyRevision)
+		if errors.Is(err, ErrRevisionMismatch) {
+			// the history was modified concurrently
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if file.License.Trial {
+			// another trial accepted concurrently for the installation wins
+			if err := ledger.Accept(ctx, file); err != nil {
+				return nil, fmt.Errorf("license file recorded but not accepted as the trial of its installation: %w", err)
+			}
+		}
+		if err := h.RollForward(ctx); err != nil {
+			return nil, fmt.Errorf("license file recorded but not stored: %w", err)
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("cannot record the license file in the history: %w", ErrRevisionMismatch)
+}
+
+// RollForward stores the most recently recorded license file at LicenseKey(),
+// if it is not the active license already.
+func (h *History) RollForward(ctx context.Context) error {
+	for attempt := 0; attempt < maxCompareAndSwapAttempts; attempt++ {
+		records, _, err := h.list(ctx)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		value, err := json.Marshal(records[len(records)-1].LicenseFile)
+		if err != nil {
+			return err
+		}
+
+		var revision int64
+		entry, err := h.store.Get(ctx, LicenseKey())
+		if err == nil {
+			if bytes.Equal(entry.Value, value) {
+				return nil
+			}
+			revision = entry.Revision
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		_, err = h.store.CompareAndSwap(ctx, LicenseKey(), value, revision)
+		if errors.Is(err, ErrRevisionMismatch) {
+			// another license file was applied concurrently, check that it is
+			// the most recent one
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("cannot store the license file: %w", ErrRevisionMismatch)
+}
+
+// List returns the recorded license files, from the oldest to the most
+// recently applied.
+func (h *History) List(ctx context.Context) ([]*HistoryRecord, error) {
+	records, _, err := h.list(ctx)
+	return records, err
+}
+
+// Get returns the license file applied at the given revision, or ErrNotFound
+// if it is not part of the history.
+func (h *History) Get(ctx context.Context, revision int64) (*HistoryRecord, error) {
+	records, _, err := h.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.Revision == revision {
+			return record, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no license applied at revision %d", ErrNotFound, revision)
+}
+
+// Rollback applies the license file that was applied at the given revision
+// again, provided that it is still valid.
+func (h *History) Rollback(ctx context.Context, revision int64, appliedBy string) (*HistoryRecord, error) {
+	record, err := h.Get(ctx, revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.validator.Validate(record.LicenseFile); err != nil {
+		return nil, fmt.Errorf("cannot roll back to revision %d: %w", revision, err)
+	}
+	return h.apply(ctx, record.LicenseFile, appliedBy)
+}
+
+// list returns the recorded license files along with the revision of the
+// history entry, which is zero when there is no history yet.
+func (h *History) list(ctx context.Context) ([]*HistoryRecord, int64, error) {
+	entry, err := h.store.Get(ctx, LicenseHistoryKey())
+	if errors.Is(err, ErrNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var records []*HistoryRecord
+	if err := json.Unmarshal(entry.Value, &records); err != nil {
+		return nil, 0, err
+	}
+	return records, entry.Revision, nil
+}
@@ -0,0 +1,154 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signedMockLicenseFile(t *testing.T, accountName string, validUntil time.Time) *LicenseFile {
+	t.Helper()
+	file := testMockLicenseFile()
+	file.License.AccountName = accountName
+	file.License.ValidUntil = Timestamp(validUntil)
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	history := NewHistory(store, 2, &Validator{PublicKey: testPublicKey})
+
+	first := signedMockLicenseFile(t, "first", now.Add(time.Hour))
+	second := signedMockLicenseFile(t, "second", now.Add(time.Hour))
+	third := signedMockLicenseFile(t, "third", now.Add(time.Hour))
+	expired := signedMockLicenseFile(t, "expired", now.Add(-time.Hour))
+
+	firstRecord, err := history.Apply(ctx, first, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondRecord, err := history.Apply(ctx, second, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := history.Apply(ctx, third, "carol"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = history.Apply(ctx, expired, "dave")
+	assert.ErrorIs(t, err, ErrExpired, "invalid licenses cannot be applied")
+
+	active, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "third", active.License.AccountName)
+
+	records, err := history.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, records, 2, "the history should be bounded") {
+		assert.Equal(t, "second", records[0].LicenseFile.License.AccountName)
+		assert.Equal(t, "bob", records[0].AppliedBy)
+		assert.Equal(t, "third", records[1].LicenseFile.License.AccountName)
+	}
+
+	_, err = history.Get(ctx, firstRecord.Revision)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	record, err := history.Get(ctx, secondRecord.Revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, second.License, record.LicenseFile.License)
+}
+
+func TestHistoryRollbackExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	history := NewHistory(store, 0, &Validator{PublicKey: testPublicKey})
+
+	// a license file that expired since it was applied
+	value, err := json.Marshal([]*HistoryRecord{
+		{Revision: 1, AppliedBy: "alice", LicenseFile: signedMockLicenseFile(t, "expired", now.Add(-time.Hour))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put(ctx, LicenseHistoryKey(), value); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = history.Rollback(ctx, 1, "bob")
+	assert.ErrorIs(t, err, ErrExpired, "expired licenses cannot be rolled back to")
+}
+
+func TestHistoryRollForward(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	history := NewHistory(store, 0, &Validator{PublicKey: testPublicKey})
+
+	first := signedMockLicenseFile(t, "first", now.Add(time.Hour))
+	second := signedMockLicenseFile(t, "second", now.Add(time.Hour))
+	if _, err := history.Apply(ctx, first, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	// an application interrupted after recording the license file
+	if _, err := history.Apply(ctx, second, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutLicenseFile(ctx, store, first); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := history.RollForward(ctx); err != nil {
+		t.Fatal(err)
+	}
+	active, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, second.License, active.License)
+}
+
+func TestHistoryRollback(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	history := NewHistory(store, 0, &Validator{PublicKey: testPublicKey})
+
+	first := signedMockLicenseFile(t, "first", now.Add(time.Hour))
+	second := signedMockLicenseFile(t, "second", now.Add(time.Hour))
+	firstRecord, err := history.Apply(ctx, first, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := history.Apply(ctx, second, "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := history.Rollback(ctx, firstRecord.Revision, "carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "carol", record.AppliedBy)
+	assert.Greater(t, record.Revision, firstRecord.Revision)
+
+	active, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, first.License, active.License)
+
+	records, err := history.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, records, 3)
+}
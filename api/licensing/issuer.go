@@ -0,0 +1,330 @@
+package licensing
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sensu/core/v3/types"
+)
+
+// IssuerPath is the path under which an Issuer accepts license specs.
+const IssuerPath = "/licenses"
+
+// maxSpecSize is the maximum size of a license spec accepted by an Issuer.
+const maxSpecSize = 1 << 20
+
+// Issuer is an http.Handler that mints signed licenses from license specs.
+// POST requests to IssuerPath accept a YAML or JSON LicenseSpec and respond
+// with the wrapped LicenseFile, and GET requests list the issued licenses.
+// Each issued license is recorded in a store at IssuedLicenseKey(), so that
+// it can still be verified and activated after a restart.
+type Issuer struct {
+	// MaxActivations is the number of installations each license can be
+	// activated on.
+	MaxActivations int
+
+	catalog PlanCatalog
+	signer  crypto.Signer
+	store   Store
+
+	// now returns the time at which licenses are issued.
+	now func() time.Time
+}
+
+// NewIssuer creates an Issuer that expands specs with the given plan catalog,
+// signs the resulting licenses with the given signer and records them in the
+// given store.
+func NewIssuer(catalog PlanCatalog, signer crypto.Signer, store Store) *Issuer {
+	return &Issuer{
+		MaxActivations: DefaultMaxActivations,
+		catalog:        catalog,
+		signer:         signer,
+		store:          store,
+		now:            time.Now,
+	}
+}
+
+// Issue expands and signs the license described by the spec, and records it.
+func (i *Issuer) Issue(ctx context.Context, spec *LicenseSpec) (*LicenseFile, error) {
+	license, err := spec.Expand(i.catalog, i.now())
+	if err != nil {
+		return nil, err
+	}
+
+	file := &LicenseFile{License: license}
+	if err := SignLicenseFileWithSigner(file, i.signer); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	// license IDs are derived from their signature, which is salted, so an
+	// existing license means it was recorded already
+	if _, err := i.store.CompareAndSwap(ctx, IssuedLicenseKey(file.ID()), value, 0); err != nil {
+		return nil, fmt.Errorf("cannot record the issued license: %w", err)
+	}
+	return file, nil
+}
+
+// Issued returns the licenses issued so far, in the order they were issued.
+func (i *Issuer) Issued(ctx context.Context) ([]*LicenseFile, error) {
+	entries, err := i.store.List(ctx, IssuedLicensesKeyBuilder.BuildPrefix())
+	if err != nil {
+		return nil, err
+	}
+	issued := make([]*LicenseFile, 0, len(entries))
+	for _, entry := range entries {
+		file := &LicenseFile{}
+		if err := json.Unmarshal(entry.Value, file); err != nil {
+			return nil, err
+		}
+		issued = append(issued, file)
+	}
+	sort.SliceStable(issued, func(a, b int) bool {
+		return time.Time(issued[a].License.Issued).Before(time.Time(issued[b].License.Issued))
+	})
+	return issued, nil
+}
+
+// issuedLicense returns the issued license with the given ID, or
+// ErrUnknownLicense.
+func (i *Issuer) issuedLicense(ctx context.Context, licenseID string) (*LicenseFile, error) {
+	// license IDs are hex-encoded SHA-256 digests, which cannot build keys
+	// outside of the issued licenses
+	if _, err := hex.DecodeString(licenseID); err != nil || len(licenseID) != 2*sha256.Size {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLicense, licenseID)
+	}
+	entry, err := i.store.Get(ctx, IssuedLicenseKey(licenseID))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLicense, licenseID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	file := &LicenseFile{}
+	if err := json.Unmarshal(entry.Value, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// VerifyUsageReport verifies that the usage report refers to a license issued
+// by the issuer to the same account, and activated on the reporting
+// installation, which must also match the binding of the license. The
+// signature of the report is verified with the public key the installation
+// was activated with.
+func (i *Issuer) VerifyUsageReport(ctx context.Context, report *SignedUsageReport) error {
+	file, err := i.issuedLicense(ctx, report.Report.LicenseID)
+	if err != nil {
+		return err
+	}
+	records, _, err := i.activations(ctx, file.ID())
+	if err != nil {
+		return err
+	}
+	installationID := report.Report.Installation.Fingerprint
+	var installationPublicKey string
+	for _, record := range records {
+		if record.InstallationID == installationID {
+			installationPublicKey = record.PublicKey
+		}
+	}
+	if installationPublicKey == "" {
+		return fmt.Errorf("%w: %s", ErrNotActivated, installationID)
+	}
+	if err := report.Verify(installationPublicKey); err != nil {
+		return fmt.Errorf("invalid usage report signature: %w", err)
+	}
+	if file.License.AccountID != report.Report.AccountID {
+		return fmt.Errorf("usage report account %d does not match license account %d", report.Report.AccountID, file.License.AccountID)
+	}
+	if err := file.License.Binding.Check(&report.Report.Installation); err != nil {
+		return fmt.Errorf("usage report installation does not match the license: %w", err)
+	}
+	return nil
+}
+
+// Activate verifies the activation request with the PEM encoded public key of
+// the installation that produced it, and returns the signed token activating
+// the license on the installation. The license must require activation, and be
+// bound to the installation if it is bound to fingerprints. Each license can
+// be activated on up to MaxActivations installations, which must keep using
+// the key of their first activation.
+func (i *Issuer) Activate(ctx context.Context, request *SignedActivationRequest, installationPublicKey string) (*SignedActivationToken, error) {
+	if err := request.Verify(installationPublicKey); err != nil {
+		return nil, fmt.Errorf("invalid activation request signature: %w", err)
+	}
+	file, err := i.issuedLicense(ctx, request.Request.LicenseID)
+	if err != nil {
+		return nil, err
+	}
+	if !file.License.ActivationRequired {
+		return nil, fmt.Errorf("%w: license %s does not require activation", ErrActivationRefused, file.ID())
+	}
+	if binding := file.License.Binding; binding != nil && len(binding.Fingerprints) > 0 {
+		identity := &InstallationIdentity{ClusterID: binding.ClusterID, Fingerprint: request.Request.InstallationID}
+		if err := binding.Check(identity); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrActivationRefused, err)
+		}
+	}
+	if err := i.recordActivation(ctx, request.Request, installationPublicKey); err != nil {
+		return nil, err
+	}
+
+	token := ActivationToken{
+		InstallationID:   request.Request.InstallationID,
+		LicenseID:        request.Request.LicenseID,
+		Nonce:            request.Request.Nonce,
+		Activated:        Timestamp(i.now().Truncate(time.Second)),
+		SignatureOptions: DefaultSignatureOptions,
+	}
+	data, err := json.Marshal(&token)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signData(data, i.signer, &token.SignatureOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedActivationToken{Token: token, Signature: signature}, nil
+}
+
+// activationRecord is an installation a license was activated on.
+type activationRecord struct {
+	// InstallationID is the fingerprint of the installation.
+	InstallationID string `json:"installationID"`
+	// PublicKey is the PEM encoded public key of the installation, which
+	// signed its first activation request.
+	PublicKey string `json:"publicKey"`
+}
+
+// recordActivation records the activation of the license of the request on
+// its installation, unless the license was activated on MaxActivations other
+// installations already, or on the same installation with another key.
+func (i *Issuer) recordActivation(ctx context.Context, request ActivationRequest, installationPublicKey string) error {
+	for attempt := 0; attempt < maxCompareAndSwapAttempts; attempt++ {
+		records, revision, err := i.activations(ctx, request.LicenseID)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if record.InstallationID != request.InstallationID {
+				continue
+			}
+			if record.PublicKey != installationPublicKey {
+				return fmt.Errorf("%w: installation %s was activated with another key", ErrActivationRefused, request.InstallationID)
+			}
+			// the installation is activated again, e.g. after losing its token
+			return nil
+		}
+		if len(records) >= i.MaxActivations {
+			return fmt.Errorf("%w: license %s was activated on %d installations already", ErrActivationRefused, request.LicenseID, len(records))
+		}
+
+		records = append(records, activationRecord{InstallationID: request.InstallationID, PublicKey: installationPublicKey})
+		value, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		_, err = i.store.CompareAndSwap(ctx, ActivationsKey(request.LicenseID), value, revision)
+		if errors.Is(err, ErrRevisionMismatch) {
+			// another installation was activated concurrently
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("cannot record the activation: %w", ErrRevisionMismatch)
+}
+
+// activations returns the installations the license was activated on, along
+// with the revision of their entry, which is zero when the license was not
+// activated yet.
+func (i *Issuer) activations(ctx context.Context, licenseID string) ([]activationRecord, int64, error) {
+	entry, err := i.store.Get(ctx, ActivationsKey(licenseID))
+	if errors.Is(err, ErrNotFound) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	var records []activationRecord
+	if err := json.Unmarshal(entry.Value, &records); err != nil {
+		return nil, 0, err
+	}
+	return records, entry.Revision, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (i *Issuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != IssuerPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		// read one more byte than allowed to detect oversized specs
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxSpecSize+1))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxSpecSize {
+			http.Error(w, fmt.Sprintf("license spec exceeds %d bytes", maxSpecSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		spec, err := ParseLicenseSpec(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, err := i.Issue(r.Context(), spec)
+		if errors.Is(err, ErrRevisionMismatch) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, types.WrapResource(file))
+	case http.MethodGet:
+		issued, err := i.Issued(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		wrapped := make([]types.Wrapper, 0, len(issued))
+		for _, file := range issued {
+			wrapped = append(wrapped, types.WrapResource(file))
+		}
+		writeJSON(w, http.StatusOK, wrapped)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// writeJSON writes the JSON encoding of v as the response body.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,107 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sensu/core/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuer(t *testing.T) {
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	issuer := NewIssuer(catalog, key, store)
+	server := httptest.NewServer(issuer)
+	defer server.Close()
+
+	spec := `
+issuer: Sensu, Inc.
+accountName: Acme Corp.
+accountID: 573
+plan: enterprise
+`
+	resp, err := http.Post(server.URL+IssuerPath, "application/yaml", strings.NewReader(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	wrapper := &types.Wrapper{}
+	if err := json.NewDecoder(resp.Body).Decode(wrapper); err != nil {
+		t.Fatal(err)
+	}
+	file, ok := wrapper.Value.(*LicenseFile)
+	if !ok {
+		t.Fatalf("expected a LicenseFile, got %T", wrapper.Value)
+	}
+	assert.Equal(t, "Acme Corp.", file.License.AccountName)
+	assert.Equal(t, 1000, file.License.EntityLimit)
+
+	data, err := json.Marshal(file.License)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(data, file.Signature, file.License.SignatureOptions, testPublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// the issued licenses are read back from the store
+	issued, err := NewIssuer(catalog, key, store).Issued(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, issued, 1)
+	assert.Equal(t, file.Signature, issued[0].Signature)
+
+	// each license is recorded under its own key
+	_, err = store.Get(context.Background(), IssuedLicenseKey(file.ID()))
+	assert.NoError(t, err)
+}
+
+func TestIssuerErrors(t *testing.T) {
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := NewIssuer(PlanCatalog{}, key, NewMemoryStore())
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{name: "unknown plan", method: http.MethodPost, path: IssuerPath, body: `{"issuer":"Sensu","accountName":"Acme","plan":"gold"}`, want: http.StatusBadRequest},
+		{name: "malformed spec", method: http.MethodPost, path: IssuerPath, body: `{`, want: http.StatusBadRequest},
+		{name: "oversized spec", method: http.MethodPost, path: IssuerPath, body: strings.Repeat(" ", maxSpecSize+1), want: http.StatusRequestEntityTooLarge},
+		{name: "unsupported method", method: http.MethodDelete, path: IssuerPath, want: http.StatusMethodNotAllowed},
+		{name: "unknown path", method: http.MethodPost, path: "/other", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			issuer.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+	issued, err := issuer.Issued(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, issued)
+}
@@ -1,6 +1,10 @@
 package licensing
 
-import "path"
+import (
+	"fmt"
+	"path"
+	"strings"
+)
 
 const (
 	// Root is the root of the sensu keyspace.
@@ -20,6 +24,36 @@ func NewKeyBuilder(resourceName string) KeyBuilder {
 	return builder
 }
 
+// WithNamespace returns a copy of the builder that builds keys within the
+// given namespace. Namespaces that do not come from a validated license must
+// be checked with ValidateNamespace first, since invalid namespaces could
+// build keys outside of the namespace.
+func (b KeyBuilder) WithNamespace(namespace string) KeyBuilder {
+	b.namespace = namespace
+	return b
+}
+
+// ValidateNamespace returns an error if the namespace is empty, or if it
+// could build keys outside of the namespace, by containing the key separator
+// or by being "." or "..".
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return fmt.Errorf("%w: empty namespace", ErrInvalidKey)
+	}
+	if strings.Contains(namespace, keySeparator) || namespace == "." || namespace == ".." {
+		return fmt.Errorf("%w: invalid namespace %q", ErrInvalidKey, namespace)
+	}
+	return nil
+}
+
+// WithExactMatch returns a copy of the builder that terminates keys with the
+// key separator, so that listing the sub-resources of a resource does not
+// also match the resources whose name starts with the same characters.
+func (b KeyBuilder) WithExactMatch() KeyBuilder {
+	b.includeTrailingSlash = true
+	return b
+}
+
 // Build builds a key from the components it is given.
 func (b KeyBuilder) Build(keys ...string) string {
 	items := append(
@@ -44,9 +78,38 @@ func (b KeyBuilder) Build(keys ...string) string {
 	}
 
 	// Be specific when listing sub-resources for a specific resource.
-	if b.includeTrailingSlash {
+	if b.includeTrailingSlash && !strings.HasSuffix(key, keySeparator) {
 		key += keySeparator
 	}
 
 	return key
 }
+
+// BuildPrefix builds a key prefix matching every key under the components it
+// is given. The prefix always ends with the key separator, so that it never
+// matches keys of other namespaces or resources sharing the same beginning.
+func (b KeyBuilder) BuildPrefix(keys ...string) string {
+	return b.WithExactMatch().Build(keys...)
+}
+
+// Range returns the range of keys [start, end) under the components it is
+// given, as expected by range requests to etcd.
+func (b KeyBuilder) Range(keys ...string) (start, end string) {
+	start = b.BuildPrefix(keys...)
+	return start, prefixRangeEnd(start)
+}
+
+// prefixRangeEnd returns the smallest key greater than every key starting
+// with prefix.
+func prefixRangeEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// the prefix only holds 0xff bytes, which etcd denotes by a range end of
+	// "\x00" meaning every key greater than or equal to the start
+	return "\x00"
+}
@@ -0,0 +1,70 @@
+package licensing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	builder := NewKeyBuilder("quotas")
+
+	tests := []struct {
+		name    string
+		builder KeyBuilder
+		keys    []string
+		want    string
+	}{
+		{name: "resource", builder: builder, want: "/sensu.io/quotas"},
+		{name: "resource with keys", builder: builder, keys: []string{"a", "b"}, want: "/sensu.io/quotas/a/b"},
+		{name: "namespace", builder: builder.WithNamespace("default"), want: "/sensu.io/quotas/default/"},
+		{name: "namespace with key", builder: builder.WithNamespace("default"), keys: []string{"agent"}, want: "/sensu.io/quotas/default/agent"},
+		{name: "namespace with empty key", builder: builder.WithNamespace("default"), keys: []string{""}, want: "/sensu.io/quotas/default/"},
+		{name: "exact match", builder: builder.WithExactMatch(), keys: []string{"a"}, want: "/sensu.io/quotas/a/"},
+		{name: "exact match with namespace", builder: builder.WithNamespace("dev").WithExactMatch(), want: "/sensu.io/quotas/dev/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.builder.Build(tt.keys...))
+		})
+	}
+
+	assert.Equal(t, "/sensu.io/quotas", builder.Build(), "builders should not be modified by With methods")
+}
+
+func TestValidateNamespace(t *testing.T) {
+	assert.NoError(t, ValidateNamespace("default"))
+	assert.NoError(t, ValidateNamespace("dev.acme"))
+	assert.NoError(t, ValidateNamespace("a..b"))
+	assert.NoError(t, ValidateNamespace("..a"))
+	for _, namespace := range []string{"", ".", "..", "../license", "a/b", "a/.."} {
+		assert.ErrorIs(t, ValidateNamespace(namespace), ErrInvalidKey, namespace)
+	}
+
+	// namespaces are validated where they enter the store
+	store := NewMemoryStore()
+	_, err := GetEntityUsage(context.Background(), store, "..")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.ErrorIs(t, PutEntityUsage(context.Background(), store, "..", map[string]int{"agent": 1}), ErrInvalidKey)
+}
+
+func TestKeyBuilderPrefix(t *testing.T) {
+	builder := NewKeyBuilder("quotas")
+	assert.Equal(t, "/sensu.io/quotas/", builder.BuildPrefix())
+	assert.Equal(t, "/sensu.io/quotas/dev/", builder.WithNamespace("dev").BuildPrefix())
+	assert.Equal(t, "/sensu.io/quotas/dev/agent/", builder.WithNamespace("dev").BuildPrefix("agent"))
+
+	start, end := builder.WithNamespace("dev").Range()
+	assert.Equal(t, "/sensu.io/quotas/dev/", start)
+	assert.Equal(t, "/sensu.io/quotas/dev0", end)
+
+	// keys of namespaces sharing the same beginning are out of range
+	other := builder.WithNamespace("development").Build("agent")
+	assert.False(t, start <= other && other < end)
+	inside := builder.WithNamespace("dev").Build("agent")
+	assert.True(t, start <= inside && inside < end)
+
+	assert.Equal(t, "b", prefixRangeEnd("a\xff"))
+	assert.Equal(t, "\x00", prefixRangeEnd("\xff"))
+}
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sort"
 	"time"
 
 	corev2 "github.com/sensu/core/v2"
+	corev3 "github.com/sensu/core/v3"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -25,29 +28,48 @@ var (
 	ErrUnsupportedVersion = errors.New("Unsupported license format version")
 	// ErrExpired means the license has expired.
 	ErrExpired = errors.New("License has expired")
+	// ErrNamespaced means a namespace was set on a license file, which is a
+	// cluster-wide resource.
+	ErrNamespaced = errors.New("license files are not namespaced")
+	// ErrInstallationRequired means a license bound to installations, or
+	// requiring activation, was validated without an installation.
+	ErrInstallationRequired = errors.New("license is locked to installations, validate it with a Validator configured with the installation Identity and ActivationToken")
+)
+
+var (
+	_ corev2.Resource       = new(LicenseFile)
+	_ corev3.Resource       = new(LicenseFile)
+	_ corev3.GlobalResource = new(LicenseFile)
 )
 
 // LicenseFile represents the content of a license file, which contains the
-// license itself and its signature
+// license itself and its signature. It is both a core/v2 and a core/v3
+// resource.
 type LicenseFile struct {
 	// License contains the actual license
-	License License `json:"license"`
+	License License `json:"license" yaml:"license"`
 	// Signature contains the cryptographical hash of the license
-	Signature []byte `json:"signature"`
+	Signature []byte `json:"signature" yaml:"signature"`
 
 	// ObjectMeta contains the name, namespace, labels and annotations
-	ObjectMeta corev2.ObjectMeta `json:"metadata"`
+	ObjectMeta corev2.ObjectMeta `json:"metadata" yaml:"metadata"`
 }
 
-// GetObjectMeta returns empty metadata because only a single license is
-// supported
+// GetObjectMeta returns the metadata of the license file. Its name is always
+// LicenseResource, since a single cluster-wide license is stored at
+// LicenseKey().
 func (f *LicenseFile) GetObjectMeta() corev2.ObjectMeta {
-	return f.ObjectMeta
+	meta := f.ObjectMeta
+	meta.Name = LicenseResource
+	return meta
 }
 
-// SetObjectMeta sets ObjectMeta to the provided metadata.
+// SetObjectMeta sets ObjectMeta to the provided metadata. The name of the
+// metadata is ignored, see GetObjectMeta. Its namespace is kept, so that
+// license files set with a namespace can be refused, e.g. by a LicenseHandler.
 func (f *LicenseFile) SetObjectMeta(meta corev2.ObjectMeta) {
-	// no-op
+	f.ObjectMeta = meta
+	f.normalizeObjectMeta()
 }
 
 // GetTypeMeta sets the correct type meta of a license file.
@@ -58,9 +80,26 @@ func (f *LicenseFile) GetTypeMeta() corev2.TypeMeta {
 	}
 }
 
-// SetNamespace sets the namespace of the resource.
+// SetNamespace implements corev2.Resource. License files are not namespaced,
+// but the namespace is kept like by SetObjectMeta, so that it can be refused;
+// use TrySetNamespace to refuse it at once.
 func (f *LicenseFile) SetNamespace(namespace string) {
-	return
+	f.ObjectMeta.Namespace = namespace
+}
+
+// TrySetNamespace returns ErrNamespaced unless the namespace is empty, since
+// license files are not namespaced, and clears the namespace otherwise.
+func (f *LicenseFile) TrySetNamespace(namespace string) error {
+	if namespace != "" {
+		return fmt.Errorf("%w: %s", ErrNamespaced, namespace)
+	}
+	f.ObjectMeta.Namespace = ""
+	return nil
+}
+
+// IsGlobalResource returns true, since license files are not namespaced.
+func (f *LicenseFile) IsGlobalResource() bool {
+	return true
 }
 
 // StorePrefix returns the path prefix to the license in the store
@@ -68,47 +107,56 @@ func (f *LicenseFile) StorePrefix() string {
 	return path.Join(apiKeyPrefix, LicenseResource)
 }
 
+// StoreName returns the name of the license file resource in the store
 func (f *LicenseFile) StoreName() string {
 	return "license_file"
 }
 
+// GetMetadata returns the metadata of the license file. Its name is set to
+// LicenseResource by the setters and when the license file is decoded, see
+// GetObjectMeta.
 func (f *LicenseFile) GetMetadata() *corev2.ObjectMeta {
 	return &f.ObjectMeta
 }
 
+// SetMetadata sets ObjectMeta to the provided metadata, see SetObjectMeta.
 func (f *LicenseFile) SetMetadata(meta *corev2.ObjectMeta) {
-	f.ObjectMeta = *meta
+	if meta == nil {
+		meta = &corev2.ObjectMeta{}
+	}
+	f.SetObjectMeta(*meta)
 }
 
-// URIPath returns the path component of the license
-func (f *LicenseFile) URIPath() string {
-	return LicenseURI()
+// normalizeObjectMeta ties the name of the license file to the single license
+// key.
+func (f *LicenseFile) normalizeObjectMeta() {
+	f.ObjectMeta.Name = LicenseResource
 }
 
-// Validate checks that the content of the license is valid
-func (f *LicenseFile) Validate() error {
-	data, err := json.Marshal(f.License)
-	if err != nil {
-		return err
-	}
-
-	if err := VerifySignature(data, f.Signature, f.License.SignatureOptions, SensuPublicSigningKey); err != nil {
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *LicenseFile) UnmarshalJSON(b []byte) error {
+	// licenseFile has the fields of LicenseFile, but not its methods
+	type licenseFile LicenseFile
+	if err := json.Unmarshal(b, (*licenseFile)(f)); err != nil {
 		return err
 	}
+	f.normalizeObjectMeta()
+	return nil
+}
 
-	if f.License.Version != SupportedLicenseVersion {
-		return ErrUnsupportedVersion
-	}
-
-	if err := f.ValidateEntityClasses(); err != nil {
-		return err
-	}
+// URIPath returns the path component of the license
+func (f *LicenseFile) URIPath() string {
+	return LicenseURI()
+}
 
-	now := time.Now()
-	if now.After(time.Time(f.License.ValidUntil)) {
-		return ErrExpired
-	}
-	return nil
+// Validate checks the signature and the content of the license, and that it
+// has not expired. The binding and the activation of the license depend on the
+// installation, so licenses bound to installations or requiring activation are
+// refused with ErrInstallationRequired, and must be validated by a Validator
+// configured for the installation instead.
+// The result is cached by the default validator, see Validator.
+func (f *LicenseFile) Validate() error {
+	return defaultValidator.cachedValidate(f, false)
 }
 
 // EntityLimit returns the entity limit of the license
@@ -121,35 +169,68 @@ func (f *LicenseFile) EntityClassLimits() map[string]int {
 	return f.License.EntityClassLimits
 }
 
+// NamespaceLimits returns the namespace limits of the license
+func (f *LicenseFile) NamespaceLimits() map[string]NamespaceLimit {
+	return f.License.NamespaceLimits
+}
+
 // License holds information about a user's enterprise software license,
 // including duration of validity and enabled features.
 type License struct {
 	// Version is the license format version.
-	Version int `json:"version"`
+	Version int `json:"version" yaml:"version"`
 	// Issuer is the name of the account that issued the license.
-	Issuer string `json:"issuer"`
+	Issuer string `json:"issuer" yaml:"issuer"`
 	// AccountName is the name of the customer account.
-	AccountName string `json:"accountName"`
+	AccountName string `json:"accountName" yaml:"accountName"`
 	// AccountID is the ID of the customer account.
-	AccountID uint64 `json:"accountID"`
+	AccountID uint64 `json:"accountID" yaml:"accountID"`
 	// Issued is the time at which the license was issued.
-	Issued Timestamp `json:"issued"`
+	Issued Timestamp `json:"issued" yaml:"issued"`
 	// ValidUntil is the time at which the license will expire.
-	ValidUntil Timestamp `json:"validUntil"`
+	ValidUntil Timestamp `json:"validUntil" yaml:"validUntil"`
 	// Plan is the subscription plan the license is associated with.
-	Plan string `json:"plan"`
+	Plan string `json:"plan" yaml:"plan"`
 	// Features are a list of features enabled by this license.
-	Features FeatureList `json:"features"`
+	Features FeatureList `json:"features" yaml:"features"`
 	// SignatureOptions contains signature algorithm and related parameters. This
 	// signature metadata must be part of the signed license data to prevent
 	// signature substitution attacks.
-	SignatureOptions SignatureOptions `json:"signature"`
+	SignatureOptions SignatureOptions `json:"signature" yaml:"signature"`
 	// EntityLimit is the limit of the total number of entities allowed.
-	EntityLimit int `json:"entityLimit,omitempty"`
+	EntityLimit int `json:"entityLimit,omitempty" yaml:"entityLimit,omitempty"`
 	// AllowTessenOptOut is a special case to allow licensed users to opt out of Tessen.
-	AllowTessenOptOut bool `json:"allowTessenOptOut,omitempty"`
+	AllowTessenOptOut bool `json:"allowTessenOptOut,omitempty" yaml:"allowTessenOptOut,omitempty"`
 	// EntityClassLimits is the limit of entities per entity class.
-	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty"`
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty" yaml:"entityClassLimits,omitempty"`
+	// NamespaceLimits are the entity limits of namespaces, carved out of the
+	// entity limits of the license.
+	NamespaceLimits map[string]NamespaceLimit `json:"namespaceLimits,omitempty" yaml:"namespaceLimits,omitempty"`
+	// OveragePolicy is the policy applied to entities beyond the entity
+	// limits. Entities beyond the limits are denied when nil.
+	OveragePolicy *OveragePolicy `json:"overagePolicy,omitempty" yaml:"overagePolicy,omitempty"`
+	// Binding restricts the installations the license can be applied to. The
+	// license can be applied to any installation when nil.
+	Binding *Binding `json:"binding,omitempty" yaml:"binding,omitempty"`
+	// ActivationRequired indicates that the license is only valid on the
+	// installations it was activated on with an activation token.
+	ActivationRequired bool `json:"activationRequired,omitempty" yaml:"activationRequired,omitempty"`
+	// Trial indicates that the license is a trial, signed by the installation
+	// it is bound to rather than by Sensu.
+	Trial bool `json:"trial,omitempty" yaml:"trial,omitempty"`
+	// AddOn indicates that the license is an add-on, extending the base
+	// license of the same account rather than replacing it.
+	AddOn bool `json:"addOn,omitempty" yaml:"addOn,omitempty"`
+}
+
+// NamespaceLimit holds the entity limits of a namespace.
+type NamespaceLimit struct {
+	// EntityLimit is the limit of the total number of entities allowed in the
+	// namespace.
+	EntityLimit int `json:"entityLimit,omitempty" yaml:"entityLimit,omitempty"`
+	// EntityClassLimits is the limit of entities per entity class in the
+	// namespace.
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty" yaml:"entityClassLimits,omitempty"`
 }
 
 // FeatureList is a list of features enabled for a license.
@@ -157,23 +238,32 @@ type FeatureList []string
 
 // SignatureOptions contains signature algorithm and related parameters.
 type SignatureOptions struct {
-	Algorithm  string        `json:"algorithm"`
-	Hash       HashAlgorithm `json:"hashAlgorithm"`
-	SaltLength int           `json:"saltLength"`
+	Algorithm  string        `json:"algorithm" yaml:"algorithm"`
+	Hash       HashAlgorithm `json:"hashAlgorithm" yaml:"hashAlgorithm"`
+	SaltLength int           `json:"saltLength" yaml:"saltLength"`
 }
 
-// HashAlgorithm is a crypto.Hash with custom JSON marshal/unmarshal.
+// HashAlgorithm is a crypto.Hash with custom JSON and YAML marshal/unmarshal.
 type HashAlgorithm crypto.Hash
 
-// MarshalJSON implements the json.Marshaler interface.
-func (ha HashAlgorithm) MarshalJSON() ([]byte, error) {
+// name returns the serialized name of the hash algorithm.
+func (ha HashAlgorithm) name() (string, error) {
 	hashAlgorithmEncoder := map[crypto.Hash]string{
 		crypto.SHA256: "SHA256",
 	}
 
 	hashName, ok := hashAlgorithmEncoder[crypto.Hash(ha)]
 	if !ok {
-		return []byte{}, fmt.Errorf("Cannot serialize unsupported hash algorithm with id: %v", ha)
+		return "", fmt.Errorf("Cannot serialize unsupported hash algorithm with id: %v", ha)
+	}
+	return hashName, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ha HashAlgorithm) MarshalJSON() ([]byte, error) {
+	hashName, err := ha.name()
+	if err != nil {
+		return []byte{}, err
 	}
 	hashBytes := []byte(fmt.Sprintf("\"%s\"", hashName))
 	return hashBytes, nil
@@ -197,14 +287,33 @@ func (ha *HashAlgorithm) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// GetHashAlgorithm returns the proper hash algorithm based on the provided name
-func GetHashAlgorithm(name string) (HashAlgorithm, error) {
-	hashAlgorithmDecoder := map[string]HashAlgorithm{
-		// allow for upper or lowercase "sha"
-		"SHA256": HashAlgorithm(crypto.SHA256),
-		"sha256": HashAlgorithm(crypto.SHA256),
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ha HashAlgorithm) MarshalYAML() (interface{}, error) {
+	return ha.name()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ha *HashAlgorithm) UnmarshalYAML(node *yaml.Node) error {
+	var name string
+	if err := node.Decode(&name); err != nil {
+		return fmt.Errorf("Cannot unmarshal the license hash algorithm")
 	}
 
+	var err error
+	*ha, err = GetHashAlgorithm(name)
+	return err
+}
+
+// hashAlgorithmDecoder maps the names of the supported hash algorithms to
+// their HashAlgorithm.
+var hashAlgorithmDecoder = map[string]HashAlgorithm{
+	// allow for upper or lowercase "sha"
+	"SHA256": HashAlgorithm(crypto.SHA256),
+	"sha256": HashAlgorithm(crypto.SHA256),
+}
+
+// GetHashAlgorithm returns the proper hash algorithm based on the provided name
+func GetHashAlgorithm(name string) (HashAlgorithm, error) {
 	hashAlgorithm, ok := hashAlgorithmDecoder[name]
 	if !ok {
 		return 0, fmt.Errorf("Unknown or unsupported license hash algorithm '%s'", name)
@@ -213,7 +322,19 @@ func GetHashAlgorithm(name string) (HashAlgorithm, error) {
 	return hashAlgorithm, nil
 }
 
-// Timestamp is an alias to time.Time with json Marshaling/Unmarshaling support
+// SupportedHashAlgorithms returns the sorted names of the supported hash
+// algorithms, as accepted by GetHashAlgorithm.
+func SupportedHashAlgorithms() []string {
+	names := make([]string, 0, len(hashAlgorithmDecoder))
+	for name := range hashAlgorithmDecoder {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Timestamp is an alias to time.Time with json and yaml Marshaling/Unmarshaling
+// support
 type Timestamp time.Time
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -238,6 +359,27 @@ func (t *Timestamp) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t Timestamp) MarshalYAML() (interface{}, error) {
+	return time.Time(t).Format(TimestampFormat), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *Timestamp) UnmarshalYAML(node *yaml.Node) error {
+	var str string
+	if err := node.Decode(&str); err != nil {
+		return fmt.Errorf("Cannot unmarshal the license timestamp")
+	}
+
+	value, err := time.Parse(TimestampFormat, str)
+	if err != nil {
+		return err
+	}
+
+	*t = Timestamp(value)
+	return nil
+}
+
 // String implements the time.Time string method
 func (t Timestamp) String() string {
 	return time.Time(t).String()
@@ -248,12 +390,19 @@ func (f *LicenseFile) RBACName() string {
 	return LicenseResource
 }
 
-// ValidateEntityClasses validates the entity classes of the license file.
+// ValidateEntityClasses validates the entity classes of the license file
+// against the registered entity classes.
 func (f *LicenseFile) ValidateEntityClasses() error {
+	return f.validateEntityClasses(IsEntityClassRegistered)
+}
+
+// validateEntityClasses validates the entity classes of the license file,
+// which must be supported.
+func (f *LicenseFile) validateEntityClasses(supported func(string) bool) error {
 	var sum int
 	totalLimit := f.License.EntityLimit
 	for entityClass, limit := range f.License.EntityClassLimits {
-		if !(entityClass == corev2.EntityProxyClass || entityClass == corev2.EntityAgentClass) {
+		if !supported(entityClass) {
 			return fmt.Errorf("unsupported entity class: %s", entityClass)
 		}
 		sum += limit
@@ -263,3 +412,48 @@ func (f *LicenseFile) ValidateEntityClasses() error {
 	}
 	return nil
 }
+
+// ValidateNamespaceLimits validates that the namespace limits of the license
+// file fit within its entity limits, and only limit registered entity classes.
+func (f *LicenseFile) ValidateNamespaceLimits() error {
+	return f.validateNamespaceLimits(IsEntityClassRegistered)
+}
+
+// validateNamespaceLimits validates that the namespace limits of the license
+// file fit within its entity limits, and only limit supported entity classes.
+func (f *LicenseFile) validateNamespaceLimits(supported func(string) bool) error {
+	var sum int
+	classSums := map[string]int{}
+	for namespace, limits := range f.License.NamespaceLimits {
+		if err := ValidateNamespace(namespace); err != nil {
+			return fmt.Errorf("invalid namespace limits: %w", err)
+		}
+		var namespaceSum int
+		for entityClass, limit := range limits.EntityClassLimits {
+			if !supported(entityClass) {
+				return fmt.Errorf("unsupported entity class in namespace %s: %s", namespace, entityClass)
+			}
+			namespaceSum += limit
+			classSums[entityClass] += limit
+		}
+		if limits.EntityLimit != 0 && namespaceSum > limits.EntityLimit {
+			return fmt.Errorf("entity class limits exceed entity limit of namespace %s: %d > %d", namespace, namespaceSum, limits.EntityLimit)
+		}
+		// namespaces without an entity limit are bounded by their entity
+		// class limits
+		if limits.EntityLimit > namespaceSum {
+			namespaceSum = limits.EntityLimit
+		}
+		sum += namespaceSum
+	}
+	if totalLimit := f.License.EntityLimit; totalLimit != 0 && sum > totalLimit {
+		return fmt.Errorf("namespace entity limits exceed total entity limit: %d > %d", sum, totalLimit)
+	}
+	for entityClass, classSum := range classSums {
+		limit, ok := f.License.EntityClassLimits[entityClass]
+		if ok && classSum > limit {
+			return fmt.Errorf("namespace limits exceed the limit of entity class %s: %d > %d", entityClass, classSum, limit)
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"time"
 )
 
 // hash generates the cryptographic hash of the given data
@@ -24,17 +25,24 @@ func hash(data []byte, hashAlgorithm crypto.Hash) ([]byte, error) {
 // options specified in the license, and fills the signature field with the
 // computed signature
 func SignLicenseFile(file *LicenseFile, privateKeyPem string) error {
-	encodedLicense, err := json.Marshal(&file.License)
+	pk, err := LoadPrivateKey(privateKeyPem)
 	if err != nil {
 		return err
 	}
 
-	pk, err := loadPrivateKey(privateKeyPem)
+	return SignLicenseFileWithSigner(file, pk)
+}
+
+// SignLicenseFileWithSigner signs the provided license file with the given
+// signer, which must hold an RSA key, by using the signature options specified
+// in the license
+func SignLicenseFileWithSigner(file *LicenseFile, signer crypto.Signer) error {
+	encodedLicense, err := json.Marshal(&file.License)
 	if err != nil {
 		return err
 	}
 
-	signature, err := signData(encodedLicense, pk, &file.License.SignatureOptions)
+	signature, err := signData(encodedLicense, signer, &file.License.SignatureOptions)
 	if err != nil {
 		return err
 	}
@@ -43,8 +51,23 @@ func SignLicenseFile(file *LicenseFile, privateKeyPem string) error {
 	return nil
 }
 
-// loadPrivateKey from PEM format
-func loadPrivateKey(pemData string) (*rsa.PrivateKey, error) {
+// IssueLicenseFile expands the provided spec into a license issued at the given
+// time, and signs it with the provided private key
+func IssueLicenseFile(spec *LicenseSpec, catalog PlanCatalog, privateKeyPem string, issued time.Time) (*LicenseFile, error) {
+	license, err := spec.Expand(catalog, issued)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &LicenseFile{License: license}
+	if err := SignLicenseFile(file, privateKeyPem); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// LoadPrivateKey loads an RSA private key from PEM format
+func LoadPrivateKey(pemData string) (*rsa.PrivateKey, error) {
 	// get next pem encoded block, and throw away rest of input
 	block, _ := pem.Decode([]byte(pemData))
 	if block == nil || block.Type != "RSA PRIVATE KEY" {
@@ -56,7 +79,7 @@ func loadPrivateKey(pemData string) (*rsa.PrivateKey, error) {
 }
 
 // signData based on the given signature options
-func signData(data []byte, pk *rsa.PrivateKey, so *SignatureOptions) ([]byte, error) {
+func signData(data []byte, signer crypto.Signer, so *SignatureOptions) ([]byte, error) {
 	hashAlgorithm := crypto.Hash(so.Hash)
 	hash, _ := hash(data, hashAlgorithm)
 
@@ -64,5 +87,5 @@ func signData(data []byte, pk *rsa.PrivateKey, so *SignatureOptions) ([]byte, er
 		SaltLength: so.SaltLength,
 		Hash:       hashAlgorithm,
 	}
-	return rsa.SignPSS(rand.Reader, pk, hashAlgorithm, hash, &pssOptions)
+	return signer.Sign(rand.Reader, hash, &pssOptions)
 }
@@ -0,0 +1,275 @@
+package licensing
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSignatureOptions are the signature options used for licenses issued
+// from a LicenseSpec.
+var DefaultSignatureOptions = SignatureOptions{
+	Algorithm:  "PSS",
+	Hash:       HashAlgorithm(crypto.SHA256),
+	SaltLength: 20,
+}
+
+// Duration is a time.Duration that can also be expressed in days, e.g. "30d",
+// when used in license specifications.
+type Duration time.Duration
+
+// DurationPattern is a regular expression matching the duration strings
+// accepted by ParseDuration. Like the components of time.ParseDuration, each
+// component has at least one digit, before or after its decimal point.
+const DurationPattern = `^([-+]?[0-9]+d|[-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+))$`
+
+// ParseDuration parses a duration string. In addition to the units supported
+// by time.ParseDuration, a whole number of days may be given with the "d"
+// suffix.
+func ParseDuration(s string) (Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return Duration(time.Duration(days) * 24 * time.Hour), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return Duration(d), nil
+}
+
+// String returns the duration in days when it is a whole number of days, and
+// in the time.Duration format otherwise.
+func (d Duration) String() string {
+	day := 24 * time.Hour
+	if td := time.Duration(d); td != 0 && td%day == 0 {
+		return fmt.Sprintf("%dd", td/day)
+	}
+	return time.Duration(d).String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return fmt.Errorf("cannot unmarshal the duration: %s", err)
+	}
+	value, err := ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var str string
+	if err := node.Decode(&str); err != nil {
+		return fmt.Errorf("cannot unmarshal the duration: %s", err)
+	}
+	value, err := ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+// LicenseSpec is a declarative description of a license to issue. Any of the
+// optional fields left empty are filled from its plan.
+type LicenseSpec struct {
+	// Issuer is the name of the account that issues the license.
+	Issuer string `json:"issuer" yaml:"issuer"`
+	// AccountName is the name of the customer account.
+	AccountName string `json:"accountName" yaml:"accountName"`
+	// AccountID is the ID of the customer account.
+	AccountID uint64 `json:"accountID" yaml:"accountID"`
+	// Plan is the name of the plan to expand.
+	Plan string `json:"plan" yaml:"plan"`
+	// Features optionally replaces the features of the plan.
+	Features FeatureList `json:"features,omitempty" yaml:"features,omitempty"`
+	// EntityLimit optionally replaces the entity limit of the plan.
+	EntityLimit int `json:"entityLimit,omitempty" yaml:"entityLimit,omitempty"`
+	// EntityClassLimits optionally replaces the entity class limits of the
+	// plan.
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty" yaml:"entityClassLimits,omitempty"`
+	// NamespaceLimits optionally carves the entity limits between namespaces.
+	NamespaceLimits map[string]NamespaceLimit `json:"namespaceLimits,omitempty" yaml:"namespaceLimits,omitempty"`
+	// OveragePolicy optionally sets the overage policy of the license.
+	OveragePolicy *OveragePolicy `json:"overagePolicy,omitempty" yaml:"overagePolicy,omitempty"`
+	// Binding optionally binds the license to installations.
+	Binding *Binding `json:"binding,omitempty" yaml:"binding,omitempty"`
+	// ActivationRequired requires the license to be activated before use.
+	ActivationRequired bool `json:"activationRequired,omitempty" yaml:"activationRequired,omitempty"`
+	// AddOn issues an add-on to the base license of the account.
+	AddOn bool `json:"addOn,omitempty" yaml:"addOn,omitempty"`
+	// AllowTessenOptOut requests Tessen opt-out, if the plan allows it.
+	AllowTessenOptOut bool `json:"allowTessenOptOut,omitempty" yaml:"allowTessenOptOut,omitempty"`
+	// Duration optionally replaces the validity period of the plan.
+	Duration Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// ParseLicenseSpec parses a YAML (or JSON) license specification.
+func ParseLicenseSpec(data []byte) (*LicenseSpec, error) {
+	spec := &LicenseSpec{}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("cannot parse license spec: %s", err)
+	}
+	return spec, nil
+}
+
+// Expand builds the license described by the spec, using its plan in the
+// catalog for defaults. The license is issued at the given time and is valid
+// for the resulting duration. The resulting license is validated against the
+// catalog.
+func (s *LicenseSpec) Expand(catalog PlanCatalog, issued time.Time) (License, error) {
+	if s.Issuer == "" {
+		return License{}, errors.New("license spec must specify an issuer")
+	}
+	if s.AccountName == "" {
+		return License{}, errors.New("license spec must specify an account name")
+	}
+	plan, err := catalog.Plan(s.Plan)
+	if err != nil {
+		return License{}, err
+	}
+
+	// timestamps are serialized with a precision of one second
+	issued = issued.Truncate(time.Second)
+
+	license := License{
+		Version:            SupportedLicenseVersion,
+		Issuer:             s.Issuer,
+		AccountName:        s.AccountName,
+		AccountID:          s.AccountID,
+		Issued:             Timestamp(issued),
+		Plan:               s.Plan,
+		Features:           copyFeatures(plan.Features),
+		SignatureOptions:   DefaultSignatureOptions,
+		EntityLimit:        plan.EntityLimit,
+		AllowTessenOptOut:  s.AllowTessenOptOut,
+		EntityClassLimits:  copyLimits(plan.EntityClassLimits),
+		NamespaceLimits:    copyNamespaceLimits(s.NamespaceLimits),
+		OveragePolicy:      copyOveragePolicy(s.OveragePolicy),
+		Binding:            copyBinding(s.Binding),
+		ActivationRequired: s.ActivationRequired,
+		AddOn:              s.AddOn,
+	}
+	if len(s.Features) > 0 {
+		license.Features = copyFeatures(s.Features)
+	}
+	if s.EntityLimit != 0 {
+		license.EntityLimit = s.EntityLimit
+	}
+	if s.EntityClassLimits != nil {
+		license.EntityClassLimits = copyLimits(s.EntityClassLimits)
+	}
+
+	duration := plan.Duration
+	if s.Duration != 0 {
+		duration = s.Duration
+	}
+	if duration <= 0 {
+		return License{}, fmt.Errorf("plan %q has no duration", s.Plan)
+	}
+	license.ValidUntil = Timestamp(issued.Add(time.Duration(duration)))
+
+	file := LicenseFile{License: license}
+	if err := file.ValidateEntityClasses(); err != nil {
+		return License{}, err
+	}
+	if err := file.ValidateNamespaceLimits(); err != nil {
+		return License{}, err
+	}
+	if err := license.OveragePolicy.Validate(); err != nil {
+		return License{}, err
+	}
+	if err := license.Binding.Validate(); err != nil {
+		return License{}, err
+	}
+	if err := catalog.ValidateLicense(&license); err != nil {
+		return License{}, err
+	}
+	return license, nil
+}
+
+// copyFeatures returns a copy of the feature list, so that issued licenses do
+// not share it with the plan catalog or the spec.
+func copyFeatures(features FeatureList) FeatureList {
+	if features == nil {
+		return nil
+	}
+	return append(FeatureList{}, features...)
+}
+
+// copyLimits returns a copy of the entity class limits, so that issued
+// licenses do not share them with the plan catalog or the spec.
+func copyLimits(limits map[string]int) map[string]int {
+	if limits == nil {
+		return nil
+	}
+	copied := make(map[string]int, len(limits))
+	for entityClass, limit := range limits {
+		copied[entityClass] = limit
+	}
+	return copied
+}
+
+// copyNamespaceLimits returns a deep copy of the namespace limits, so that
+// issued licenses do not share them with the spec.
+func copyNamespaceLimits(limits map[string]NamespaceLimit) map[string]NamespaceLimit {
+	if limits == nil {
+		return nil
+	}
+	copied := make(map[string]NamespaceLimit, len(limits))
+	for namespace, limit := range limits {
+		copied[namespace] = NamespaceLimit{
+			EntityLimit:       limit.EntityLimit,
+			EntityClassLimits: copyLimits(limit.EntityClassLimits),
+		}
+	}
+	return copied
+}
+
+// copyOveragePolicy returns a copy of the overage policy, so that issued
+// licenses do not share it with the spec.
+func copyOveragePolicy(policy *OveragePolicy) *OveragePolicy {
+	if policy == nil {
+		return nil
+	}
+	copied := *policy
+	return &copied
+}
+
+// copyBinding returns a deep copy of the binding, so that issued licenses do
+// not share it with the spec.
+func copyBinding(binding *Binding) *Binding {
+	if binding == nil {
+		return nil
+	}
+	copied := *binding
+	if binding.Fingerprints != nil {
+		copied.Fingerprints = append([]string{}, binding.Fingerprints...)
+	}
+	return &copied
+}
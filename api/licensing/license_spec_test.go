@@ -0,0 +1,157 @@
+package licensing
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPlanCatalog = `
+enterprise:
+  features: [all]
+  entityLimit: 1000
+  maxEntityLimit: 5000
+  entityClassLimits:
+    agent: 800
+  duration: 365d
+trial:
+  features: [all]
+  entityLimit: 100
+  duration: 720h
+`
+
+func TestParseDuration(t *testing.T) {
+	durationRe := regexp.MustCompile(DurationPattern)
+	tests := []struct {
+		input   string
+		want    Duration
+		wantErr bool
+	}{
+		{input: "30d", want: Duration(30 * 24 * time.Hour)},
+		{input: "1h30m", want: Duration(90 * time.Minute)},
+		{input: "1.5h", want: Duration(90 * time.Minute)},
+		{input: "0", want: 0},
+		{input: "-0", want: 0},
+		{input: "-1d", want: Duration(-24 * time.Hour)},
+		{input: "+2d", want: Duration(48 * time.Hour)},
+		{input: "-1h", want: Duration(-time.Hour)},
+		{input: ".5s", want: Duration(500 * time.Millisecond)},
+		{input: "5.s", want: Duration(5 * time.Second)},
+		{input: "1ms", want: Duration(time.Millisecond)},
+		{input: "2µs", want: Duration(2 * time.Microsecond)},
+		{input: "xd", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "1.5d", wantErr: true},
+		{input: "h", wantErr: true},
+		{input: ".s", wantErr: true},
+		{input: "1h.m", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "forever", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, !tt.wantErr, durationRe.MatchString(tt.input))
+		})
+	}
+	assert.Equal(t, "30d", Duration(30*24*time.Hour).String())
+	assert.Equal(t, "1h30m0s", Duration(90*time.Minute).String())
+}
+
+func TestLicenseSpecExpand(t *testing.T) {
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := ParseLicenseSpec([]byte(`
+issuer: Sensu, Inc.
+accountName: Acme Corp.
+accountID: 573
+plan: enterprise
+entityLimit: 2000
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	license, err := spec.Expand(catalog, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, SupportedLicenseVersion, license.Version)
+	assert.Equal(t, "Acme Corp.", license.AccountName)
+	assert.Equal(t, uint64(573), license.AccountID)
+	assert.Equal(t, FeatureList{"all"}, license.Features)
+	assert.Equal(t, 2000, license.EntityLimit)
+	assert.Equal(t, map[string]int{"agent": 800}, license.EntityClassLimits)
+	assert.Equal(t, Timestamp(now), license.Issued)
+	assert.Equal(t, Timestamp(now.Add(365*24*time.Hour)), license.ValidUntil)
+	assert.Equal(t, DefaultSignatureOptions, license.SignatureOptions)
+
+	// the license does not share the maps of the catalog
+	license.Features[0] = "rbac"
+	license.EntityClassLimits["agent"] = 1
+	assert.Equal(t, FeatureList{"all"}, catalog["enterprise"].Features)
+	assert.Equal(t, map[string]int{"agent": 800}, catalog["enterprise"].EntityClassLimits)
+
+	// nor the limits, policies and binding of the spec
+	spec.NamespaceLimits = map[string]NamespaceLimit{"dev": {EntityLimit: 100, EntityClassLimits: map[string]int{"agent": 10}}}
+	spec.OveragePolicy = &OveragePolicy{Mode: OverageWarn}
+	spec.Binding = &Binding{Fingerprints: []string{"install-1"}}
+	license, err = spec.Expand(catalog, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	license.NamespaceLimits["dev"].EntityClassLimits["agent"] = 1
+	license.NamespaceLimits["prod"] = NamespaceLimit{EntityLimit: 1}
+	license.OveragePolicy.Mode = OverageBlock
+	license.Binding.Fingerprints[0] = "install-2"
+	assert.Equal(t, map[string]NamespaceLimit{"dev": {EntityLimit: 100, EntityClassLimits: map[string]int{"agent": 10}}}, spec.NamespaceLimits)
+	assert.Equal(t, &OveragePolicy{Mode: OverageWarn}, spec.OveragePolicy)
+	assert.Equal(t, &Binding{Fingerprints: []string{"install-1"}}, spec.Binding)
+
+	spec.Plan = "unknown"
+	_, err = spec.Expand(catalog, now)
+	assert.Error(t, err)
+
+	spec.Plan = "trial"
+	spec.EntityLimit = 0
+	spec.EntityClassLimits = map[string]int{"agent": 200}
+	_, err = spec.Expand(catalog, now)
+	assert.Error(t, err, "class limits exceeding the entity limit should be refused")
+}
+
+func TestIssueLicenseFile(t *testing.T) {
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := &LicenseSpec{
+		Issuer:      "Sensu, Inc.",
+		AccountName: "Acme Corp.",
+		AccountID:   573,
+		Plan:        "trial",
+	}
+
+	file, err := IssueLicenseFile(spec, catalog, testPrivateKey, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(file.License)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(data, file.Signature, file.License.SignatureOptions, testPublicKey); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, Timestamp(now.Add(720*time.Hour)), file.License.ValidUntil)
+}
@@ -0,0 +1,145 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/core/v2"
+	"github.com/sensu/core/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseFileObjectMeta(t *testing.T) {
+	file := &LicenseFile{}
+	assert.Equal(t, corev2.ObjectMeta{Name: LicenseResource}, file.GetObjectMeta())
+
+	file.SetObjectMeta(corev2.ObjectMeta{
+		Name:        "other",
+		Namespace:   "default",
+		Labels:      map[string]string{"team": "ops"},
+		Annotations: map[string]string{"ticket": "42"},
+		CreatedBy:   "alice",
+	})
+	meta := file.GetObjectMeta()
+	assert.Equal(t, LicenseResource, meta.Name, "the name should be tied to the license key")
+	assert.Equal(t, "default", meta.Namespace, "the namespace should not be silently dropped")
+	assert.Equal(t, map[string]string{"team": "ops"}, meta.Labels)
+	assert.Equal(t, map[string]string{"ticket": "42"}, meta.Annotations)
+	assert.Equal(t, "alice", meta.CreatedBy)
+	assert.Equal(t, &meta, file.GetMetadata())
+
+	assert.ErrorIs(t, file.TrySetNamespace(meta.Namespace), ErrNamespaced)
+
+	file.SetMetadata(&corev2.ObjectMeta{Labels: map[string]string{"team": "dev"}})
+	assert.Equal(t, map[string]string{"team": "dev"}, file.GetObjectMeta().Labels)
+	assert.Nil(t, file.GetObjectMeta().Annotations)
+	file.SetMetadata(nil)
+	assert.Equal(t, corev2.ObjectMeta{Name: LicenseResource}, file.GetObjectMeta())
+
+	file.SetNamespace("default")
+	assert.Equal(t, "default", file.GetObjectMeta().Namespace)
+	assert.ErrorIs(t, file.TrySetNamespace("default"), ErrNamespaced)
+	assert.NoError(t, file.TrySetNamespace(""))
+	assert.Empty(t, file.GetObjectMeta().Namespace)
+
+	// the name is set on decode rather than by the getters
+	decoded := &LicenseFile{}
+	if err := json.Unmarshal([]byte(`{"metadata":{"name":"other","labels":{"team":"ops"}}}`), decoded); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, LicenseResource, decoded.GetMetadata().Name)
+	assert.Equal(t, map[string]string{"team": "ops"}, decoded.GetMetadata().Labels)
+}
+
+func TestLicenseFileResource(t *testing.T) {
+	file := testMockLicenseFile()
+	assert.Equal(t, corev2.TypeMeta{APIVersion: "licensing/v2", Type: "LicenseFile"}, file.GetTypeMeta())
+	assert.Equal(t, "api/enterprise/licensing/v2/license", file.StorePrefix())
+	assert.Equal(t, "license_file", file.StoreName())
+	assert.Equal(t, LicenseURI(), file.URIPath())
+	assert.Equal(t, LicenseResource, file.RBACName())
+	assert.Error(t, file.Validate(), "licenses must be signed by Sensu")
+}
+
+func TestLicenseFileValidate(t *testing.T) {
+	defer func(validator *Validator) { defaultValidator = validator }(defaultValidator)
+	defaultValidator = &Validator{PublicKey: testPublicKey}
+
+	file := signedMockLicenseFile(t, "Acme Corp.", now.Add(time.Hour))
+	assert.NoError(t, file.Validate())
+
+	// bound licenses must be validated by validators for the installation
+	bound := testMockLicenseFile()
+	bound.License.Binding = &Binding{ClusterID: "cluster-1"}
+	if err := SignLicenseFile(bound, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	assert.ErrorIs(t, bound.Validate(), ErrInstallationRequired)
+	assert.ErrorIs(t, defaultValidator.Validate(bound), ErrNoInstallationIdentity)
+	validator := &Validator{PublicKey: testPublicKey, Identity: &InstallationIdentity{ClusterID: "cluster-1"}}
+	assert.NoError(t, validator.Validate(bound))
+
+	activated := testMockLicenseFile()
+	activated.License.ActivationRequired = true
+	if err := SignLicenseFile(activated, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	assert.ErrorIs(t, activated.Validate(), ErrInstallationRequired)
+
+	expired := signedMockLicenseFile(t, "Acme Corp.", now.Add(-time.Hour))
+	assert.ErrorIs(t, expired.Validate(), ErrExpired)
+}
+
+func TestLicenseFileMetadataPersisted(t *testing.T) {
+	handler := NewLicenseHandler(NewMemoryStore(), &Validator{PublicKey: testPublicKey})
+
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	file.SetObjectMeta(corev2.ObjectMeta{
+		Labels:      map[string]string{"team": "ops"},
+		Annotations: map[string]string{"ticket": "42"},
+	})
+	body, err := json.Marshal(types.WrapResource(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	wrapper := &types.Wrapper{}
+	if err := json.Unmarshal(rec.Body.Bytes(), wrapper); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.GetObjectMeta(), wrapper.ObjectMeta)
+	stored := wrapper.Value.(*LicenseFile)
+	assert.Equal(t, map[string]string{"team": "ops"}, stored.GetObjectMeta().Labels)
+	assert.Equal(t, map[string]string{"ticket": "42"}, stored.GetObjectMeta().Annotations)
+
+	// license files are not namespaced
+	file.SetNamespace("default")
+	body, err = json.Marshal(types.WrapResource(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), ErrNamespaced.Error())
+
+	// nor stored with a namespace outside of the handler
+	store := NewMemoryStore()
+	assert.ErrorIs(t, PutLicenseFile(context.Background(), store, file), ErrNamespaced)
+	_, err = NewHistory(store, 10, &Validator{PublicKey: testPublicKey}).Apply(context.Background(), file, "alice")
+	assert.ErrorIs(t, err, ErrNamespaced)
+	_, err = GetLicenseFile(context.Background(), store)
+	assert.ErrorIs(t, err, ErrNotFound)
+	addOn := *file
+	addOn.License.AddOn = true
+	assert.ErrorIs(t, PutAddOnLicenseFiles(context.Background(), store, []*LicenseFile{&addOn}), ErrNamespaced)
+}
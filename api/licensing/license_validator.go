@@ -3,10 +3,14 @@ package licensing
 import (
 	"crypto"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 )
 
 // SensuPublicSigningKey is a public RSA key used for license signature
@@ -22,15 +26,325 @@ oHmcLz52u2NZBlDKvOXufKsO6FJxHEe8xgeLS9tbqYoQeiC3qfKbeB0EX2COl3xV
 hwIDAQAB
 -----END PUBLIC KEY-----`
 
+// maxValidationCacheSize is the maximum number of validation results cached
+// by a Validator.
+const maxValidationCacheSize = 64
+
+// validationErrorTTL is the time for which a Validator caches a failed
+// validation, so that a license file fixed in the meantime, e.g. by
+// registering its entity classes, is validated again.
+const validationErrorTTL = time.Minute
+
+// defaultValidator validates license files against SensuPublicSigningKey.
+var defaultValidator = new(Validator)
+
+// Validator validates license files. The public key is only parsed again when
+// it changes, and the validation results are cached by license content,
+// signature, public keys, installation identity and activation token, so that
+// repeated validations of the same license file do not verify its signature
+// again. Successful validations are cached until the license expires, and
+// failed ones for validationErrorTTL. The oldest result is evicted once
+// maxValidationCacheSize results are cached.
+type Validator struct {
+	// PublicKey is the PEM-encoded public key used to verify license
+	// signatures. SensuPublicSigningKey is used when empty.
+	PublicKey string
+	// Identity is the identity of the installation, which licenses bound to
+	// installations must match.
+	Identity *InstallationIdentity
+	// TrialPublicKey is the PEM-encoded public key of the installation, used
+	// to verify the signatures of trial licenses. Trial licenses are refused
+	// when empty.
+	TrialPublicKey string
+	// ActivationToken is the token activating the license on the
+	// installation, which licenses requiring activation must match.
+	ActivationToken *SignedActivationToken
+	// EntityClasses are the entity classes that licenses may limit, in
+	// addition to the agent and proxy entity classes. The classes registered
+	// with RegisterEntityClass are used when nil.
+	EntityClasses []string
+
+	mu     sync.Mutex
+	keyPEM string
+	key    *rsa.PublicKey
+	keyErr error
+	cache  map[[sha256.Size]byte]validationResult
+	// order holds the keys of the cached results, from the oldest
+	order [][sha256.Size]byte
+}
+
+// validationResult is a cached validation result.
+type validationResult struct {
+	err error
+	// until is the time up to which the result holds.
+	until time.Time
+	// generation is the generation of the registered entity classes the
+	// license was validated against.
+	generation uint64
+}
+
+// Validate checks that the content of the license file is valid
+func (v *Validator) Validate(f *LicenseFile) error {
+	return v.cachedValidate(f, true)
+}
+
+// cachedValidate validates the license file, on the installation of the
+// validator unless installation is false, and caches the result.
+func (v *Validator) cachedValidate(f *LicenseFile, installation bool) error {
+	data, err := json.Marshal(f.License)
+	if err != nil {
+		return err
+	}
+
+	identity, err := json.Marshal(v.Identity)
+	if err != nil {
+		return err
+	}
+	activation, err := json.Marshal(v.ActivationToken)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.New()
+	_, _ = digest.Write(data)
+	_, _ = digest.Write(f.Signature)
+	// the keys are prefixed by their length so that they cannot be confused
+	for _, key := range []string{v.PublicKey, v.TrialPublicKey} {
+		_, _ = fmt.Fprintf(digest, "%d:%s", len(key), key)
+	}
+	_, _ = digest.Write(identity)
+	_, _ = digest.Write(activation)
+	for _, entityClass := range v.EntityClasses {
+		_, _ = fmt.Fprintf(digest, "%d:%s", len(entityClass), entityClass)
+	}
+	_, _ = fmt.Fprintf(digest, "installation:%t", installation)
+	var cacheKey [sha256.Size]byte
+	copy(cacheKey[:], digest.Sum(nil))
+
+	now := time.Now()
+	generation := registeredEntityClassesGeneration()
+	v.mu.Lock()
+	cached, ok := v.cache[cacheKey]
+	v.mu.Unlock()
+	if ok && cached.generation == generation && now.Before(cached.until) {
+		return cached.err
+	}
+
+	validate := v.validate
+	if !installation {
+		validate = v.validateAnywhere
+	}
+	result := validationResult{
+		err:        validate(f, data, now),
+		generation: generation,
+		until:      now.Add(validationErrorTTL),
+	}
+	if result.err == nil {
+		// the license becomes invalid once it expires
+		result.until = time.Time(f.License.ValidUntil)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.cache == nil {
+		v.cache = make(map[[sha256.Size]byte]validationResult)
+	}
+	if _, ok := v.cache[cacheKey]; !ok {
+		if len(v.cache) >= maxValidationCacheSize {
+			delete(v.cache, v.order[0])
+			v.order = v.order[1:]
+		}
+		v.order = append(v.order, cacheKey)
+	}
+	v.cache[cacheKey] = result
+	return result.err
+}
+
+// validate checks that the content of the license file, encoded as data, is
+// valid at the given time, on the installation of the validator.
+func (v *Validator) validate(f *LicenseFile, data []byte, now time.Time) error {
+	pubKey, err := v.validateContent(f, data)
+	if err != nil {
+		return err
+	}
+
+	if err := f.License.Binding.Check(v.Identity); err != nil {
+		return err
+	}
+
+	if err := v.checkActivation(f, pubKey); err != nil {
+		return err
+	}
+
+	if now.After(time.Time(f.License.ValidUntil)) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// validateAnywhere checks that the content of the license file, encoded as
+// data, is valid at the given time without an installation, which licenses
+// bound to installations or requiring activation are not.
+func (v *Validator) validateAnywhere(f *LicenseFile, data []byte, now time.Time) error {
+	if _, err := v.validateContent(f, data); err != nil {
+		return err
+	}
+	if f.License.Binding != nil || f.License.ActivationRequired {
+		return ErrInstallationRequired
+	}
+	if now.After(time.Time(f.License.ValidUntil)) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// validateContent checks the signature and the content of the license file,
+// encoded as data, regardless of the installation and of the current time. It
+// returns the public key of the validator.
+func (v *Validator) validateContent(f *LicenseFile, data []byte) (*rsa.PublicKey, error) {
+	pubKey, err := v.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// trial licenses are signed by the installation
+	signingKey := pubKey
+	if f.License.Trial {
+		if v.TrialPublicKey == "" {
+			return nil, ErrTrialNotAccepted
+		}
+		if signingKey, err = loadPublicKey(v.TrialPublicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := verifySignature(data, f.Signature, f.License.SignatureOptions, signingKey); err != nil {
+		return nil, err
+	}
+
+	if f.License.Version != SupportedLicenseVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	if err := f.validateEntityClasses(v.supportsEntityClass); err != nil {
+		return nil, err
+	}
+
+	if err := f.validateNamespaceLimits(v.supportsEntityClass); err != nil {
+		return nil, err
+	}
+
+	if err := f.ValidateTrial(); err != nil {
+		return nil, err
+	}
+
+	if err := f.License.OveragePolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.License.Binding.Validate(); err != nil {
+		return nil, err
+	}
+	return pubKey, nil
+}
+
+// supportsEntityClass returns whether licenses may limit entities of the given
+// class.
+func (v *Validator) supportsEntityClass(entityClass string) bool {
+	if v.EntityClasses == nil {
+		return IsEntityClassRegistered(entityClass)
+	}
+	if _, ok := builtinEntityClasses[entityClass]; ok {
+		return true
+	}
+	for _, supported := range v.EntityClasses {
+		if supported == entityClass {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationReport describes the outcome of the validation of a license file.
+type ValidationReport struct {
+	// Valid indicates whether the license file is valid.
+	Valid bool `json:"valid"`
+	// Error is the reason why the license file is invalid.
+	Error string `json:"error,omitempty"`
+	// AccountName is the name of the customer account.
+	AccountName string `json:"accountName"`
+	// AccountID is the ID of the customer account.
+	AccountID uint64 `json:"accountID"`
+	// Plan is the subscription plan the license is associated with.
+	Plan string `json:"plan"`
+	// ValidUntil is the time at which the license will expire.
+	ValidUntil Timestamp `json:"validUntil"`
+	// Features are a list of features enabled by the license.
+	Features FeatureList `json:"features"`
+	// EntityLimit is the limit of the total number of entities allowed.
+	EntityLimit int `json:"entityLimit,omitempty"`
+	// EntityClassLimits is the limit of entities per entity class.
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty"`
+	// NamespaceLimits are the entity limits of namespaces.
+	NamespaceLimits map[string]NamespaceLimit `json:"namespaceLimits,omitempty"`
+	// OveragePolicy is the policy applied to entities beyond the limits.
+	OveragePolicy *OveragePolicy `json:"overagePolicy,omitempty"`
+	// Trial indicates that the license is a trial.
+	Trial bool `json:"trial,omitempty"`
+}
+
+// Report validates the license file and summarizes the result
+func (v *Validator) Report(f *LicenseFile) *ValidationReport {
+	report := &ValidationReport{
+		Valid:             true,
+		AccountName:       f.License.AccountName,
+		AccountID:         f.License.AccountID,
+		Plan:              f.License.Plan,
+		ValidUntil:        f.License.ValidUntil,
+		Features:          f.License.Features,
+		EntityLimit:       f.License.EntityLimit,
+		EntityClassLimits: f.License.EntityClassLimits,
+		NamespaceLimits:   f.License.NamespaceLimits,
+		OveragePolicy:     f.License.OveragePolicy,
+		Trial:             f.License.Trial,
+	}
+	if err := v.Validate(f); err != nil {
+		report.Valid = false
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// publicKey returns the parsed public key of the validator.
+func (v *Validator) publicKey() (*rsa.PublicKey, error) {
+	pem := v.PublicKey
+	if pem == "" {
+		pem = SensuPublicSigningKey
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if pem != v.keyPEM {
+		v.key, v.keyErr = loadPublicKey(pem)
+		v.keyPEM = pem
+	}
+	return v.key, v.keyErr
+}
+
 // VerifySignature verifies that the license data matches its signature.
 func VerifySignature(data, signature []byte, opts SignatureOptions, pubKeyPem string) error {
-	if opts.Algorithm != "PSS" {
-		return fmt.Errorf("Unsupported signature algorithm %q", opts.Algorithm)
-	}
 	pubKey, err := loadPublicKey(pubKeyPem)
 	if err != nil {
 		return err
 	}
+	return verifySignature(data, signature, opts, pubKey)
+}
+
+// verifySignature verifies that the data matches its signature with the
+// parsed public key.
+func verifySignature(data, signature []byte, opts SignatureOptions, pubKey *rsa.PublicKey) error {
+	if opts.Algorithm != "PSS" {
+		return fmt.Errorf("Unsupported signature algorithm %q", opts.Algorithm)
+	}
 
 	hasher := crypto.Hash(opts.Hash)
 	hash, err := hash(data, hasher)
@@ -2,6 +2,8 @@ package licensing
 
 import (
 	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/json"
 	"testing"
 	"time"
@@ -200,3 +202,183 @@ func TestLicenseSignatureVerification(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// Test that validation results are cached until the license expires
+func TestValidatorCache(t *testing.T) {
+	validator := &Validator{PublicKey: testPublicKey}
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validator.Validate(file); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, validator.cache, 1)
+	if err := validator.Validate(file); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, validator.cache, 1)
+
+	// a tampered license is a different cache entry
+	tampered := testMockLicenseFile()
+	tampered.Signature = file.Signature
+	tampered.License.EntityLimit = 1000
+	assert.Error(t, validator.Validate(tampered))
+	assert.Len(t, validator.cache, 2)
+
+	// valid results only hold until the license expires, invalid ones for a
+	// short while
+	for _, result := range validator.cache {
+		if result.err == nil {
+			assert.Equal(t, time.Time(file.License.ValidUntil), result.until)
+		} else {
+			assert.WithinDuration(t, time.Now().Add(validationErrorTTL), result.until, time.Second)
+		}
+	}
+
+	// changing the public key invalidates the cached results
+	validator.PublicKey = SensuPublicSigningKey
+	assert.ErrorIs(t, validator.Validate(file), rsa.ErrVerification)
+	assert.Len(t, validator.cache, 3)
+}
+
+// Test that the oldest validation results are evicted one at a time
+func TestValidatorCacheEviction(t *testing.T) {
+	validator := &Validator{PublicKey: testPublicKey}
+	var keys [][sha256.Size]byte
+	for i := 0; i <= maxValidationCacheSize; i++ {
+		file := testMockLicenseFile()
+		file.License.AccountID = uint64(i)
+		if err := SignLicenseFile(file, testPrivateKey); err != nil {
+			t.Fatal(err)
+		}
+		if err := validator.Validate(file); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, validator.order[len(validator.order)-1])
+	}
+	assert.Len(t, validator.cache, maxValidationCacheSize)
+	assert.Equal(t, keys[1:], validator.order)
+	assert.NotContains(t, validator.cache, keys[0], "the oldest result should be evicted")
+}
+
+func TestValidateNamespaceLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		license License
+		wantErr bool
+	}{
+		{
+			name: "within limits",
+			license: License{
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 50},
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev":  {EntityLimit: 40, EntityClassLimits: map[string]int{"agent": 25}},
+					"prod": {EntityLimit: 60, EntityClassLimits: map[string]int{"agent": 25}},
+				},
+			},
+		},
+		{
+			name: "namespaces exceed entity limit",
+			license: License{
+				EntityLimit: 100,
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev":  {EntityLimit: 50},
+					"prod": {EntityLimit: 51},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "namespace class limits exceed entity limit",
+			license: License{
+				EntityLimit: 100,
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev":  {EntityLimit: 50},
+					"prod": {EntityClassLimits: map[string]int{"agent": 30, "proxy": 30}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "namespaces exceed entity class limit",
+			license: License{
+				EntityClassLimits: map[string]int{"agent": 50},
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev":  {EntityClassLimits: map[string]int{"agent": 30}},
+					"prod": {EntityClassLimits: map[string]int{"agent": 30}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "classes exceed namespace limit",
+			license: License{
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev": {EntityLimit: 10, EntityClassLimits: map[string]int{"agent": 8, "proxy": 8}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported entity class",
+			license: License{
+				NamespaceLimits: map[string]NamespaceLimit{
+					"dev": {EntityClassLimits: map[string]int{"foo": 1}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := &LicenseFile{License: tt.license}
+			err := file.ValidateNamespaceLimits()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateNamespaceLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			validator := &Validator{PublicKey: testPublicKey}
+			if err := validator.Validate(file); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		validator := &Validator{PublicKey: testPublicKey}
+		for i := 0; i < b.N; i++ {
+			if err := validator.Validate(file); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkLicenseFileValidate(b *testing.B) {
+	defer func(validator *Validator) { defaultValidator = validator }(defaultValidator)
+	defaultValidator = &Validator{PublicKey: testPublicKey}
+
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		if err := file.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package licensing
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"path"
 	"strings"
 )
@@ -12,7 +14,22 @@ const (
 	Version = "v2"
 	// LicenseResource is the name of the license resource
 	LicenseResource = "license"
-	keySeparator    = "/"
+	// LicenseHistoryResource is the name of the license history resource
+	LicenseHistoryResource = "license_history"
+	// AddOnsResource is the name of the add-on licenses resource
+	AddOnsResource = "license_addons"
+	// EntityUsageResource is the name of the entity usage resource
+	EntityUsageResource = "entity_usage"
+	// UsageRollupResource is the name of the usage rollup resource
+	UsageRollupResource = "usage_rollups"
+	// TrialResource is the name of the trial resource
+	TrialResource = "trials"
+	// IssuedLicensesResource is the name of the issued licenses resource
+	IssuedLicensesResource = "issued_licenses"
+	// ActivationsResource is the name of the license activations resource
+	ActivationsResource = "license_activations"
+	entityUsageName     = "entities"
+	keySeparator        = "/"
 )
 
 var (
@@ -22,6 +39,44 @@ var (
 	LicenseKeyBuilder = NewKeyBuilder(
 		strings.Join([]string{apiKeyPrefix, LicenseResource}, keySeparator),
 	)
+
+	// LicenseHistoryKeyBuilder is a key builder for the license history
+	LicenseHistoryKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, LicenseHistoryResource}, keySeparator),
+	)
+
+	// AddOnsKeyBuilder is a key builder for the add-on licenses
+	AddOnsKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, AddOnsResource}, keySeparator),
+	)
+
+	// EntityUsageKeyBuilder is a key builder for the entity usage of
+	// namespaces
+	EntityUsageKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, EntityUsageResource}, keySeparator),
+	)
+
+	// TrialKeyBuilder is a key builder for the trials used by installations
+	TrialKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, TrialResource}, keySeparator),
+	)
+
+	// UsageRollupKeyBuilder is a key builder for the usage rollups
+	UsageRollupKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, UsageRollupResource}, keySeparator),
+	)
+
+	// IssuedLicensesKeyBuilder is a key builder for the licenses issued by an
+	// Issuer
+	IssuedLicensesKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, IssuedLicensesResource}, keySeparator),
+	)
+
+	// ActivationsKeyBuilder is a key builder for the installations licenses
+	// issued by an Issuer were activated on
+	ActivationsKeyBuilder = NewKeyBuilder(
+		strings.Join([]string{apiKeyPrefix, ActivationsResource}, keySeparator),
+	)
 )
 
 // LicenseKey returns the key to the license
@@ -29,6 +84,52 @@ func LicenseKey() string {
 	return LicenseKeyBuilder.Build()
 }
 
+// LicenseHistoryKey returns the key to the license history
+func LicenseHistoryKey() string {
+	return LicenseHistoryKeyBuilder.Build()
+}
+
+// UsageRollupKey returns the key to the usage rollups of a granularity
+func UsageRollupKey(granularity RollupGranularity) string {
+	return UsageRollupKeyBuilder.Build(string(granularity))
+}
+
+// AddOnsKey returns the key to the add-on licenses
+func AddOnsKey() string {
+	return AddOnsKeyBuilder.Build()
+}
+
+// licensingKeyPrefix returns the prefix of the keys of every licensing
+// resource
+func licensingKeyPrefix() string {
+	return path.Join(Root, apiKeyPrefix) + keySeparator
+}
+
+// TrialKey returns the key to the trial used by an installation. The
+// installation ID is hashed, so that it cannot build keys outside of the
+// trials.
+func TrialKey(installationID string) string {
+	sum := sha256.Sum256([]byte(installationID))
+	return TrialKeyBuilder.Build(hex.EncodeToString(sum[:]))
+}
+
+// IssuedLicenseKey returns the key to a license issued by an Issuer
+func IssuedLicenseKey(licenseID string) string {
+	return IssuedLicensesKeyBuilder.Build(licenseID)
+}
+
+// ActivationsKey returns the key to the installations a license was activated
+// on, given the ID of the license
+func ActivationsKey(licenseID string) string {
+	return ActivationsKeyBuilder.Build(licenseID)
+}
+
+// EntityUsageKey returns the key to the entity usage of a namespace, which
+// must have been checked with ValidateNamespace
+func EntityUsageKey(namespace string) string {
+	return EntityUsageKeyBuilder.WithNamespace(namespace).Build(entityUsageName)
+}
+
 // LicenseURI returns the URI to the license
 func LicenseURI() string {
 	return path.Join("/", apiKeyPrefix, LicenseResource)
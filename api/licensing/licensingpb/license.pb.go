@@ -0,0 +1,3305 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: github.com/sensu/sensu-licensing/v2/api/licensing/licensingpb/license.proto
+
+package licensingpb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/golang/protobuf/proto"
+	v2 "github.com/sensu/core/v2"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// LicenseFile is the protobuf representation of a license file, which contains
+// the license itself and its signature.
+type LicenseFile struct {
+	// License contains the actual license
+	License License `protobuf:"bytes,1,opt,name=license,proto3" json:"license"`
+	// Signature contains the cryptographical hash of the JSON encoded license
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature"`
+	// Metadata contains the name, namespace, labels and annotations
+	Metadata             v2.ObjectMeta `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *LicenseFile) Reset()         { *m = LicenseFile{} }
+func (m *LicenseFile) String() string { return proto.CompactTextString(m) }
+func (*LicenseFile) ProtoMessage()    {}
+func (*LicenseFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{0}
+}
+func (m *LicenseFile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LicenseFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LicenseFile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LicenseFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LicenseFile.Merge(m, src)
+}
+func (m *LicenseFile) XXX_Size() int {
+	return m.Size()
+}
+func (m *LicenseFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_LicenseFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LicenseFile proto.InternalMessageInfo
+
+func (m *LicenseFile) GetLicense() License {
+	if m != nil {
+		return m.License
+	}
+	return License{}
+}
+
+func (m *LicenseFile) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *LicenseFile) GetMetadata() v2.ObjectMeta {
+	if m != nil {
+		return m.Metadata
+	}
+	return v2.ObjectMeta{}
+}
+
+// License holds information about a user's enterprise software license. Its
+// fields carry the values of the signed JSON encoding of the license, so that
+// the signature still verifies once it is converted back.
+type License struct {
+	// Version is the license format version.
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version"`
+	// Issuer is the name of the account that issued the license.
+	Issuer string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer"`
+	// AccountName is the name of the customer account.
+	AccountName string `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"accountName"`
+	// AccountID is the ID of the customer account.
+	AccountID uint64 `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"accountID"`
+	// Issued is the time at which the license was issued, in the RFC 3339
+	// format of the signed license, which keeps its UTC offset.
+	Issued string `protobuf:"bytes,5,opt,name=issued,proto3" json:"issued"`
+	// ValidUntil is the time at which the license will expire, in the RFC 3339
+	// format of the signed license, which keeps its UTC offset.
+	ValidUntil string `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"validUntil"`
+	// Plan is the subscription plan the license is associated with.
+	Plan string `protobuf:"bytes,7,opt,name=plan,proto3" json:"plan"`
+	// Features are the features enabled by this license. It is nil when the
+	// features of the signed license are null rather than a list.
+	Features *FeatureList `protobuf:"bytes,8,opt,name=features,proto3" json:"features"`
+	// SignatureOptions contains signature algorithm and related parameters.
+	SignatureOptions SignatureOptions `protobuf:"bytes,9,opt,name=signature_options,json=signatureOptions,proto3" json:"signature"`
+	// EntityLimit is the limit of the total number of entities allowed.
+	EntityLimit int64 `protobuf:"varint,10,opt,name=entity_limit,json=entityLimit,proto3" json:"entityLimit,omitempty"`
+	// AllowTessenOptOut is a special case to allow licensed users to opt out of
+	// Tessen.
+	AllowTessenOptOut bool `protobuf:"varint,11,opt,name=allow_tessen_opt_out,json=allowTessenOptOut,proto3" json:"allowTessenOptOut,omitempty"`
+	// EntityClassLimits is the limit of entities per entity class.
+	EntityClassLimits map[string]int64 `protobuf:"bytes,12,rep,name=entity_class_limits,json=entityClassLimits,proto3" json:"entityClassLimits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// NamespaceLimits are the entity limits of namespaces.
+	NamespaceLimits map[string]NamespaceLimit `protobuf:"bytes,13,rep,name=namespace_limits,json=namespaceLimits,proto3" json:"namespaceLimits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// OveragePolicy is the policy applied to entities beyond the entity limits.
+	OveragePolicy *OveragePolicy `protobuf:"bytes,14,opt,name=overage_policy,json=overagePolicy,proto3" json:"overagePolicy,omitempty"`
+	// Binding restricts the installations the license can be applied to.
+	Binding *Binding `protobuf:"bytes,15,opt,name=binding,proto3" json:"binding,omitempty"`
+	// ActivationRequired indicates that the license is only valid on the
+	// installations it was activated on.
+	ActivationRequired bool `protobuf:"varint,16,opt,name=activation_required,json=activationRequired,proto3" json:"activationRequired,omitempty"`
+	// Trial indicates that the license is a trial.
+	Trial bool `protobuf:"varint,17,opt,name=trial,proto3" json:"trial,omitempty"`
+	// AddOn indicates that the license is an add-on.
+	AddOn                bool     `protobuf:"varint,18,opt,name=add_on,json=addOn,proto3" json:"addOn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *License) Reset()         { *m = License{} }
+func (m *License) String() string { return proto.CompactTextString(m) }
+func (*License) ProtoMessage()    {}
+func (*License) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{1}
+}
+func (m *License) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *License) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_License.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *License) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_License.Merge(m, src)
+}
+func (m *License) XXX_Size() int {
+	return m.Size()
+}
+func (m *License) XXX_DiscardUnknown() {
+	xxx_messageInfo_License.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_License proto.InternalMessageInfo
+
+func (m *License) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *License) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *License) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *License) GetAccountID() uint64 {
+	if m != nil {
+		return m.AccountID
+	}
+	return 0
+}
+
+func (m *License) GetIssued() string {
+	if m != nil {
+		return m.Issued
+	}
+	return ""
+}
+
+func (m *License) GetValidUntil() string {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return ""
+}
+
+func (m *License) GetPlan() string {
+	if m != nil {
+		return m.Plan
+	}
+	return ""
+}
+
+func (m *License) GetFeatures() *FeatureList {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *License) GetSignatureOptions() SignatureOptions {
+	if m != nil {
+		return m.SignatureOptions
+	}
+	return SignatureOptions{}
+}
+
+func (m *License) GetEntityLimit() int64 {
+	if m != nil {
+		return m.EntityLimit
+	}
+	return 0
+}
+
+func (m *License) GetAllowTessenOptOut() bool {
+	if m != nil {
+		return m.AllowTessenOptOut
+	}
+	return false
+}
+
+func (m *License) GetEntityClassLimits() map[string]int64 {
+	if m != nil {
+		return m.EntityClassLimits
+	}
+	return nil
+}
+
+func (m *License) GetNamespaceLimits() map[string]NamespaceLimit {
+	if m != nil {
+		return m.NamespaceLimits
+	}
+	return nil
+}
+
+func (m *License) GetOveragePolicy() *OveragePolicy {
+	if m != nil {
+		return m.OveragePolicy
+	}
+	return nil
+}
+
+func (m *License) GetBinding() *Binding {
+	if m != nil {
+		return m.Binding
+	}
+	return nil
+}
+
+func (m *License) GetActivationRequired() bool {
+	if m != nil {
+		return m.ActivationRequired
+	}
+	return false
+}
+
+func (m *License) GetTrial() bool {
+	if m != nil {
+		return m.Trial
+	}
+	return false
+}
+
+func (m *License) GetAddOn() bool {
+	if m != nil {
+		return m.AddOn
+	}
+	return false
+}
+
+// FeatureList is a list of features enabled for a license. It is wrapped in a
+// message to distinguish an empty list from a null one.
+type FeatureList struct {
+	// Features are the names of the enabled features.
+	Features             []string `protobuf:"bytes,1,rep,name=features,proto3" json:"features"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeatureList) Reset()         { *m = FeatureList{} }
+func (m *FeatureList) String() string { return proto.CompactTextString(m) }
+func (*FeatureList) ProtoMessage()    {}
+func (*FeatureList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{2}
+}
+func (m *FeatureList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeatureList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeatureList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FeatureList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureList.Merge(m, src)
+}
+func (m *FeatureList) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeatureList) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeatureList proto.InternalMessageInfo
+
+func (m *FeatureList) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+// SignatureOptions contains signature algorithm and related parameters.
+type SignatureOptions struct {
+	// Algorithm is the signature algorithm, e.g. PSS.
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm"`
+	// HashAlgorithm is the name of the hash algorithm, e.g. SHA256.
+	HashAlgorithm string `protobuf:"bytes,2,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hashAlgorithm"`
+	// SaltLength is the length of the salt of PSS signatures.
+	SaltLength           int64    `protobuf:"varint,3,opt,name=salt_length,json=saltLength,proto3" json:"saltLength"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignatureOptions) Reset()         { *m = SignatureOptions{} }
+func (m *SignatureOptions) String() string { return proto.CompactTextString(m) }
+func (*SignatureOptions) ProtoMessage()    {}
+func (*SignatureOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{3}
+}
+func (m *SignatureOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignatureOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignatureOptions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignatureOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignatureOptions.Merge(m, src)
+}
+func (m *SignatureOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignatureOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignatureOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignatureOptions proto.InternalMessageInfo
+
+func (m *SignatureOptions) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *SignatureOptions) GetHashAlgorithm() string {
+	if m != nil {
+		return m.HashAlgorithm
+	}
+	return ""
+}
+
+func (m *SignatureOptions) GetSaltLength() int64 {
+	if m != nil {
+		return m.SaltLength
+	}
+	return 0
+}
+
+// NamespaceLimit holds the entity limits of a namespace.
+type NamespaceLimit struct {
+	// EntityLimit is the limit of the total number of entities allowed in the
+	// namespace.
+	EntityLimit int64 `protobuf:"varint,1,opt,name=entity_limit,json=entityLimit,proto3" json:"entityLimit,omitempty"`
+	// EntityClassLimits is the limit of entities per entity class in the
+	// namespace.
+	EntityClassLimits    map[string]int64 `protobuf:"bytes,2,rep,name=entity_class_limits,json=entityClassLimits,proto3" json:"entityClassLimits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NamespaceLimit) Reset()         { *m = NamespaceLimit{} }
+func (m *NamespaceLimit) String() string { return proto.CompactTextString(m) }
+func (*NamespaceLimit) ProtoMessage()    {}
+func (*NamespaceLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{4}
+}
+func (m *NamespaceLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceLimit.Merge(m, src)
+}
+func (m *NamespaceLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceLimit proto.InternalMessageInfo
+
+func (m *NamespaceLimit) GetEntityLimit() int64 {
+	if m != nil {
+		return m.EntityLimit
+	}
+	return 0
+}
+
+func (m *NamespaceLimit) GetEntityClassLimits() map[string]int64 {
+	if m != nil {
+		return m.EntityClassLimits
+	}
+	return nil
+}
+
+// OveragePolicy is the policy applied to entities beyond the entity limits of
+// a license.
+type OveragePolicy struct {
+	// Mode is the overage mode, either block, warn or burst.
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode"`
+	// BurstPercent is the percentage of a limit that entities may exceed it by
+	// in the burst mode.
+	BurstPercent int64 `protobuf:"varint,2,opt,name=burst_percent,json=burstPercent,proto3" json:"burstPercent,omitempty"`
+	// BurstDuration is the maximum duration of a burst above a limit in the
+	// burst mode, in nanoseconds.
+	BurstDuration        int64    `protobuf:"varint,3,opt,name=burst_duration,json=burstDuration,proto3" json:"burstDuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OveragePolicy) Reset()         { *m = OveragePolicy{} }
+func (m *OveragePolicy) String() string { return proto.CompactTextString(m) }
+func (*OveragePolicy) ProtoMessage()    {}
+func (*OveragePolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{5}
+}
+func (m *OveragePolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OveragePolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OveragePolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OveragePolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OveragePolicy.Merge(m, src)
+}
+func (m *OveragePolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *OveragePolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_OveragePolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OveragePolicy proto.InternalMessageInfo
+
+func (m *OveragePolicy) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *OveragePolicy) GetBurstPercent() int64 {
+	if m != nil {
+		return m.BurstPercent
+	}
+	return 0
+}
+
+func (m *OveragePolicy) GetBurstDuration() int64 {
+	if m != nil {
+		return m.BurstDuration
+	}
+	return 0
+}
+
+// Binding restricts the installations a license can be applied to.
+type Binding struct {
+	// ClusterID is the ID of the cluster the license is bound to.
+	ClusterID string `protobuf:"bytes,1,opt,name=cluster_id,json=clusterId,proto3" json:"clusterID,omitempty"`
+	// Fingerprints are the fingerprints of the installations the license is
+	// bound to.
+	Fingerprints         []string `protobuf:"bytes,2,rep,name=fingerprints,proto3" json:"fingerprints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Binding) Reset()         { *m = Binding{} }
+func (m *Binding) String() string { return proto.CompactTextString(m) }
+func (*Binding) ProtoMessage()    {}
+func (*Binding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca845d65207fd3a4, []int{6}
+}
+func (m *Binding) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Binding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Binding.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Binding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Binding.Merge(m, src)
+}
+func (m *Binding) XXX_Size() int {
+	return m.Size()
+}
+func (m *Binding) XXX_DiscardUnknown() {
+	xxx_messageInfo_Binding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Binding proto.InternalMessageInfo
+
+func (m *Binding) GetClusterID() string {
+	if m != nil {
+		return m.ClusterID
+	}
+	return ""
+}
+
+func (m *Binding) GetFingerprints() []string {
+	if m != nil {
+		return m.Fingerprints
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*LicenseFile)(nil), "sensu.licensing.v2.LicenseFile")
+	proto.RegisterType((*License)(nil), "sensu.licensing.v2.License")
+	proto.RegisterMapType((map[string]int64)(nil), "sensu.licensing.v2.License.EntityClassLimitsEntry")
+	proto.RegisterMapType((map[string]NamespaceLimit)(nil), "sensu.licensing.v2.License.NamespaceLimitsEntry")
+	proto.RegisterType((*FeatureList)(nil), "sensu.licensing.v2.FeatureList")
+	proto.RegisterType((*SignatureOptions)(nil), "sensu.licensing.v2.SignatureOptions")
+	proto.RegisterType((*NamespaceLimit)(nil), "sensu.licensing.v2.NamespaceLimit")
+	proto.RegisterMapType((map[string]int64)(nil), "sensu.licensing.v2.NamespaceLimit.EntityClassLimitsEntry")
+	proto.RegisterType((*OveragePolicy)(nil), "sensu.licensing.v2.OveragePolicy")
+	proto.RegisterType((*Binding)(nil), "sensu.licensing.v2.Binding")
+}
+
+func init() {
+	proto.RegisterFile("github.com/sensu/sensu-licensing/v2/api/licensing/licensingpb/license.proto", fileDescriptor_ca845d65207fd3a4)
+}
+
+var fileDescriptor_ca845d65207fd3a4 = []byte{
+	// 1113 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4d, 0x6f, 0xe3, 0x44,
+	0x18, 0x5e, 0x37, 0xdb, 0xa6, 0x19, 0x27, 0x69, 0x32, 0xed, 0x2e, 0x6e, 0xba, 0xaa, 0xd3, 0xb0,
+	0x48, 0xe1, 0x2b, 0x81, 0x2c, 0x12, 0x5d, 0x84, 0x80, 0x75, 0xbb, 0x45, 0x2b, 0xca, 0xa6, 0x18,
+	0xb8, 0x70, 0xb1, 0x26, 0xf6, 0x34, 0x1d, 0x70, 0x6c, 0xe3, 0x19, 0x07, 0xf5, 0xc2, 0x85, 0xdb,
+	0x1e, 0x38, 0x73, 0xe5, 0x86, 0x38, 0xf2, 0x2b, 0x56, 0xe2, 0xc2, 0x2f, 0xb0, 0x50, 0xb9, 0xf9,
+	0x57, 0xa0, 0x99, 0xb1, 0x93, 0x49, 0x93, 0x2e, 0x42, 0xda, 0x8b, 0xf3, 0xce, 0xf3, 0x7e, 0x3d,
+	0xf6, 0x3b, 0xf3, 0x4c, 0xc0, 0x67, 0x63, 0xc2, 0x2e, 0x92, 0x51, 0xcf, 0x0d, 0x27, 0x7d, 0x8a,
+	0x03, 0x9a, 0xc8, 0xe7, 0xdb, 0x3e, 0x71, 0x71, 0x40, 0x49, 0x30, 0xee, 0x4f, 0x07, 0x7d, 0x14,
+	0x91, 0xfe, 0x1c, 0x98, 0x59, 0xd1, 0x28, 0xb7, 0x71, 0x2f, 0x8a, 0x43, 0x16, 0x42, 0x28, 0x72,
+	0x7b, 0xb3, 0x80, 0xde, 0x74, 0xd0, 0x7a, 0x4f, 0x69, 0x30, 0x0e, 0xc7, 0x61, 0x5f, 0x84, 0x8e,
+	0x92, 0xf3, 0x4f, 0xa6, 0xef, 0xf6, 0x1e, 0xf4, 0x06, 0x02, 0x14, 0x98, 0xb0, 0x64, 0xa5, 0xd6,
+	0xab, 0x4b, 0xb4, 0xdc, 0x30, 0xc6, 0x9c, 0xcb, 0x04, 0x33, 0x24, 0x83, 0x3a, 0x7f, 0x6a, 0x40,
+	0x3f, 0x95, 0x04, 0x4e, 0x88, 0x8f, 0xe1, 0x09, 0x28, 0xe7, 0x7c, 0x0c, 0xad, 0xad, 0x75, 0xf5,
+	0xc1, 0x5e, 0x6f, 0x99, 0x50, 0x2f, 0xcf, 0xb0, 0xb6, 0x9e, 0xa7, 0xe6, 0xad, 0x2c, 0x35, 0x8b,
+	0x1c, 0xbb, 0x30, 0xe0, 0x9b, 0xa0, 0x42, 0xc9, 0x38, 0x40, 0x2c, 0x89, 0xb1, 0xb1, 0xd6, 0xd6,
+	0xba, 0x55, 0xab, 0x96, 0xa5, 0xe6, 0x1c, 0xb4, 0xe7, 0x26, 0xfc, 0x14, 0x6c, 0x72, 0x4a, 0x1e,
+	0x62, 0xc8, 0x28, 0x89, 0xae, 0xbb, 0x79, 0x57, 0xce, 0x98, 0x37, 0x1c, 0x8e, 0xbe, 0xc5, 0x2e,
+	0xfb, 0x1c, 0x33, 0x64, 0x35, 0xf2, 0x9e, 0xb3, 0x14, 0x7b, 0x66, 0x75, 0x9e, 0xe9, 0xa0, 0x9c,
+	0x73, 0x83, 0xaf, 0x81, 0xf2, 0x14, 0xc7, 0x94, 0x84, 0x81, 0x78, 0x93, 0x92, 0xa5, 0x73, 0xa2,
+	0x39, 0x64, 0x17, 0x06, 0xec, 0x80, 0x0d, 0x42, 0x69, 0x82, 0x63, 0xc1, 0xb2, 0x62, 0x81, 0x2c,
+	0x35, 0x73, 0xc4, 0xce, 0x7f, 0xe1, 0x00, 0x54, 0x91, 0xeb, 0x86, 0x49, 0xc0, 0x9c, 0x00, 0x4d,
+	0xb0, 0xe0, 0x58, 0xb1, 0xb6, 0xb2, 0xd4, 0xd4, 0x73, 0xfc, 0x29, 0x9a, 0x60, 0x5b, 0x5d, 0xc0,
+	0x87, 0x00, 0x14, 0x39, 0xc4, 0x33, 0x6e, 0xb7, 0xb5, 0xee, 0x6d, 0xab, 0x75, 0x95, 0x9a, 0x95,
+	0x47, 0x12, 0x7d, 0x72, 0xcc, 0x3f, 0x07, 0x2a, 0x16, 0xf6, 0xcc, 0xf4, 0x66, 0x94, 0x3c, 0x63,
+	0xfd, 0x1a, 0x25, 0x2f, 0xa7, 0xe4, 0xc1, 0x3e, 0xd0, 0xa7, 0xc8, 0x27, 0x9e, 0x93, 0x04, 0x8c,
+	0xf8, 0xc6, 0x86, 0x08, 0xac, 0x67, 0xa9, 0x09, 0x04, 0xfc, 0x35, 0x47, 0x6d, 0xc5, 0x86, 0xf7,
+	0xc0, 0xed, 0xc8, 0x47, 0x81, 0x51, 0x16, 0x91, 0x9b, 0x59, 0x6a, 0x8a, 0xb5, 0x2d, 0x9e, 0xf0,
+	0x09, 0xd8, 0x3c, 0xc7, 0x62, 0x18, 0xd4, 0xd8, 0x14, 0x13, 0x30, 0x57, 0xcd, 0xfd, 0x44, 0xc6,
+	0x9c, 0x12, 0xca, 0xac, 0x2a, 0x9f, 0x41, 0x91, 0x64, 0xcf, 0x2c, 0xe8, 0x81, 0xe6, 0x6c, 0xb2,
+	0x4e, 0x18, 0x31, 0x12, 0x06, 0xd4, 0xa8, 0x88, 0x9a, 0xf7, 0x57, 0xd5, 0xfc, 0xb2, 0x08, 0x1e,
+	0xca, 0x58, 0xab, 0x99, 0x0f, 0x58, 0xd9, 0x2b, 0x0d, 0x7a, 0x2d, 0x08, 0x7e, 0x08, 0xaa, 0x38,
+	0x60, 0x84, 0x5d, 0x3a, 0x3e, 0x99, 0x10, 0x66, 0x00, 0x31, 0xe2, 0xdd, 0x2c, 0x35, 0xef, 0x48,
+	0xfc, 0x94, 0xc3, 0x6f, 0x85, 0x13, 0xc2, 0xf0, 0x24, 0x62, 0x97, 0xb6, 0xae, 0xc0, 0xf0, 0x0c,
+	0xec, 0x20, 0xdf, 0x0f, 0x7f, 0x70, 0x18, 0xa6, 0x14, 0x07, 0x9c, 0xa6, 0x13, 0x26, 0xcc, 0xd0,
+	0xdb, 0x5a, 0x77, 0xd3, 0x32, 0xb3, 0xd4, 0xdc, 0x13, 0xfe, 0xaf, 0x84, 0x7b, 0x18, 0xb1, 0x61,
+	0xa2, 0xd6, 0x6a, 0x2e, 0x39, 0xe1, 0x4f, 0x1a, 0xd8, 0xce, 0x09, 0xb9, 0x3e, 0xa2, 0x54, 0xd2,
+	0xa2, 0x46, 0xb5, 0x5d, 0xea, 0xea, 0x83, 0xc1, 0x0b, 0x0e, 0x51, 0xef, 0xb1, 0x48, 0x3b, 0xe2,
+	0x59, 0x82, 0x1d, 0x7d, 0x1c, 0xb0, 0xf8, 0x52, 0xb2, 0xc0, 0xd7, 0x7d, 0x2a, 0x8b, 0x25, 0x27,
+	0xfc, 0x11, 0x34, 0xf8, 0x06, 0xa5, 0x11, 0x72, 0x71, 0xc1, 0xa0, 0x26, 0x18, 0xbc, 0xf3, 0x22,
+	0x06, 0x4f, 0x8b, 0x1c, 0xb5, 0xff, 0x41, 0x3e, 0x86, 0xdd, 0x60, 0xd1, 0xab, 0x30, 0xd8, 0xba,
+	0xe6, 0x82, 0x18, 0xd4, 0xc3, 0x29, 0x8e, 0xd1, 0x18, 0x3b, 0x51, 0xe8, 0x13, 0xf7, 0xd2, 0xa8,
+	0x8b, 0xc1, 0x1f, 0xac, 0xea, 0x3e, 0x94, 0x91, 0x67, 0x22, 0xd0, 0xda, 0xcb, 0x52, 0xf3, 0x95,
+	0x50, 0x85, 0x94, 0x46, 0xb5, 0x05, 0x07, 0x3c, 0x05, 0xe5, 0x11, 0x09, 0x3c, 0x12, 0x8c, 0x8d,
+	0xad, 0x9b, 0x45, 0xca, 0x92, 0x21, 0xd6, 0x9d, 0x2c, 0x35, 0x9b, 0x79, 0xbc, 0x52, 0xb3, 0x28,
+	0x01, 0xbf, 0x00, 0xdb, 0xc8, 0x65, 0x64, 0x8a, 0xf8, 0xce, 0x72, 0x62, 0xfc, 0x7d, 0x42, 0x62,
+	0xec, 0x19, 0x0d, 0xb1, 0x17, 0xda, 0x59, 0x6a, 0xde, 0x9b, 0xbb, 0xed, 0xdc, 0xab, 0xd4, 0x81,
+	0xcb, 0x5e, 0xf8, 0x3a, 0x58, 0x67, 0x31, 0x41, 0xbe, 0xd1, 0x14, 0x45, 0xb6, 0xb3, 0xd4, 0xdc,
+	0x12, 0x80, 0x92, 0x27, 0x23, 0xe0, 0x1b, 0x60, 0x03, 0x79, 0x9e, 0x13, 0x06, 0x06, 0x9c, 0xc7,
+	0x22, 0xcf, 0x1b, 0x06, 0x6a, 0xac, 0x00, 0x5a, 0xc7, 0xe0, 0xee, 0xea, 0xcd, 0x02, 0x1b, 0xa0,
+	0xf4, 0x1d, 0xbe, 0x14, 0x42, 0x57, 0xb1, 0xb9, 0x09, 0x77, 0xc0, 0xfa, 0x14, 0xf9, 0x89, 0x14,
+	0xdf, 0x92, 0x2d, 0x17, 0x1f, 0xac, 0x1d, 0x6a, 0xad, 0x73, 0xb0, 0xb3, 0x6a, 0xe0, 0x2b, 0x6a,
+	0x1c, 0xaa, 0x35, 0xf4, 0x41, 0x67, 0xd5, 0x57, 0x5e, 0x2c, 0xa5, 0xf4, 0xe9, 0xbc, 0x0f, 0x74,
+	0x45, 0x2f, 0x60, 0x57, 0x91, 0x18, 0xad, 0x5d, 0xea, 0x56, 0x6e, 0x52, 0x90, 0xce, 0xef, 0x1a,
+	0x68, 0x5c, 0x57, 0x05, 0x7e, 0xa1, 0x20, 0x7f, 0x1c, 0xc6, 0x84, 0x5d, 0x4c, 0x24, 0x47, 0x79,
+	0xa1, 0xcc, 0x40, 0x7b, 0x6e, 0xc2, 0x43, 0x50, 0xbf, 0x40, 0xf4, 0xc2, 0x99, 0x67, 0x48, 0x71,
+	0x6f, 0x66, 0xa9, 0x59, 0xe3, 0x9e, 0x47, 0xb3, 0xac, 0xc5, 0x25, 0xd7, 0x55, 0x8a, 0x7c, 0xe6,
+	0xf8, 0x38, 0x18, 0xb3, 0x0b, 0xa1, 0xf4, 0x25, 0xa9, 0xab, 0x1c, 0x3e, 0x15, 0xa8, 0xad, 0xd8,
+	0x9d, 0x5f, 0xd7, 0x40, 0x7d, 0xf1, 0x1b, 0x2c, 0x69, 0x93, 0xf6, 0xbf, 0xb4, 0xe9, 0xd9, 0x0d,
+	0x4a, 0xb2, 0x26, 0xce, 0xf1, 0xc3, 0xff, 0x9e, 0xc1, 0x4b, 0x14, 0x94, 0x97, 0xb3, 0xe3, 0x3a,
+	0x7f, 0x68, 0xa0, 0xb6, 0x70, 0xda, 0xf9, 0x6d, 0x34, 0x09, 0x3d, 0x6c, 0x68, 0xf3, 0xdb, 0x88,
+	0xaf, 0x6d, 0xf1, 0x84, 0x1f, 0x83, 0xda, 0x28, 0x89, 0x29, 0x73, 0x22, 0x1c, 0xbb, 0x38, 0x60,
+	0xb2, 0xa2, 0xd5, 0xca, 0x52, 0xf3, 0xae, 0x70, 0x9c, 0x49, 0x5c, 0xe1, 0x5e, 0x55, 0x71, 0x68,
+	0x81, 0xba, 0x2c, 0xe0, 0x25, 0xb1, 0x38, 0x99, 0xf9, 0x20, 0x85, 0xc8, 0x08, 0xcf, 0x71, 0xee,
+	0x50, 0x45, 0x66, 0xc1, 0xd1, 0xf9, 0x59, 0x03, 0xe5, 0x5c, 0x42, 0xe0, 0x11, 0x00, 0xae, 0x9f,
+	0x50, 0x86, 0x63, 0x7e, 0x99, 0x4b, 0xd2, 0xf7, 0xf9, 0x65, 0x7e, 0x24, 0x51, 0x71, 0x99, 0x6f,
+	0xbb, 0xc5, 0x42, 0x29, 0x5a, 0x29, 0x40, 0x0f, 0x7e, 0x04, 0xaa, 0xe7, 0x24, 0x18, 0xe3, 0x38,
+	0x8a, 0x49, 0x90, 0x0f, 0xb4, 0x22, 0x5f, 0x4a, 0xc5, 0xd5, 0x97, 0x52, 0x71, 0xeb, 0xe0, 0xb7,
+	0xab, 0x7d, 0xed, 0xf9, 0xd5, 0xbe, 0xf6, 0xd7, 0xd5, 0xbe, 0xf6, 0xf7, 0xd5, 0xbe, 0xf6, 0xcb,
+	0x3f, 0xfb, 0xb7, 0xbe, 0xd1, 0x95, 0xbf, 0x92, 0xa3, 0x0d, 0xf1, 0xa7, 0xee, 0xc1, 0xbf, 0x03,
+	0x00, 0x64, 0x19, 0xcd, 0x87, 0x92, 0x0a, 0x00, 0x00,
+}
+
+func (m *LicenseFile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LicenseFile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LicenseFile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	{
+		size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintLicense(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.License.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintLicense(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *License) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *License) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *License) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.AddOn {
+		i--
+		if m.AddOn {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.Trial {
+		i--
+		if m.Trial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.ActivationRequired {
+		i--
+		if m.ActivationRequired {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.Binding != nil {
+		{
+			size, err := m.Binding.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLicense(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.OveragePolicy != nil {
+		{
+			size, err := m.OveragePolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLicense(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.NamespaceLimits) > 0 {
+		for k := range m.NamespaceLimits {
+			v := m.NamespaceLimits[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLicense(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLicense(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLicense(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.EntityClassLimits) > 0 {
+		for k := range m.EntityClassLimits {
+			v := m.EntityClassLimits[k]
+			baseI := i
+			i = encodeVarintLicense(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLicense(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLicense(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.AllowTessenOptOut {
+		i--
+		if m.AllowTessenOptOut {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.EntityLimit != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.EntityLimit))
+		i--
+		dAtA[i] = 0x50
+	}
+	{
+		size, err := m.SignatureOptions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintLicense(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	if m.Features != nil {
+		{
+			size, err := m.Features.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLicense(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Plan) > 0 {
+		i -= len(m.Plan)
+		copy(dAtA[i:], m.Plan)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Plan)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.ValidUntil) > 0 {
+		i -= len(m.ValidUntil)
+		copy(dAtA[i:], m.ValidUntil)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.ValidUntil)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Issued) > 0 {
+		i -= len(m.Issued)
+		copy(dAtA[i:], m.Issued)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Issued)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.AccountID != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.AccountID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.AccountName) > 0 {
+		i -= len(m.AccountName)
+		copy(dAtA[i:], m.AccountName)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.AccountName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Issuer) > 0 {
+		i -= len(m.Issuer)
+		copy(dAtA[i:], m.Issuer)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Issuer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintLicense(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignatureOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignatureOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignatureOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SaltLength != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.SaltLength))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.HashAlgorithm) > 0 {
+		i -= len(m.HashAlgorithm)
+		copy(dAtA[i:], m.HashAlgorithm)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.HashAlgorithm)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Algorithm) > 0 {
+		i -= len(m.Algorithm)
+		copy(dAtA[i:], m.Algorithm)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Algorithm)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NamespaceLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NamespaceLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.EntityClassLimits) > 0 {
+		for k := range m.EntityClassLimits {
+			v := m.EntityClassLimits[k]
+			baseI := i
+			i = encodeVarintLicense(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLicense(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLicense(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.EntityLimit != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.EntityLimit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OveragePolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OveragePolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OveragePolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.BurstDuration != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.BurstDuration))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BurstPercent != 0 {
+		i = encodeVarintLicense(dAtA, i, uint64(m.BurstPercent))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Mode) > 0 {
+		i -= len(m.Mode)
+		copy(dAtA[i:], m.Mode)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.Mode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Binding) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Binding) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Binding) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Fingerprints) > 0 {
+		for iNdEx := len(m.Fingerprints) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fingerprints[iNdEx])
+			copy(dAtA[i:], m.Fingerprints[iNdEx])
+			i = encodeVarintLicense(dAtA, i, uint64(len(m.Fingerprints[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ClusterID) > 0 {
+		i -= len(m.ClusterID)
+		copy(dAtA[i:], m.ClusterID)
+		i = encodeVarintLicense(dAtA, i, uint64(len(m.ClusterID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLicense(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLicense(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (this *LicenseFile) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LicenseFile)
+	if !ok {
+		that2, ok := that.(LicenseFile)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.License.Equal(&that1.License) {
+		return false
+	}
+	if !bytes.Equal(this.Signature, that1.Signature) {
+		return false
+	}
+	if !this.Metadata.Equal(&that1.Metadata) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *License) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*License)
+	if !ok {
+		that2, ok := that.(License)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if this.Issuer != that1.Issuer {
+		return false
+	}
+	if this.AccountName != that1.AccountName {
+		return false
+	}
+	if this.AccountID != that1.AccountID {
+		return false
+	}
+	if this.Issued != that1.Issued {
+		return false
+	}
+	if this.ValidUntil != that1.ValidUntil {
+		return false
+	}
+	if this.Plan != that1.Plan {
+		return false
+	}
+	if !this.Features.Equal(that1.Features) {
+		return false
+	}
+	if !this.SignatureOptions.Equal(&that1.SignatureOptions) {
+		return false
+	}
+	if this.EntityLimit != that1.EntityLimit {
+		return false
+	}
+	if this.AllowTessenOptOut != that1.AllowTessenOptOut {
+		return false
+	}
+	if len(this.EntityClassLimits) != len(that1.EntityClassLimits) {
+		return false
+	}
+	for i := range this.EntityClassLimits {
+		if this.EntityClassLimits[i] != that1.EntityClassLimits[i] {
+			return false
+		}
+	}
+	if len(this.NamespaceLimits) != len(that1.NamespaceLimits) {
+		return false
+	}
+	for i := range this.NamespaceLimits {
+		a := this.NamespaceLimits[i]
+		b := that1.NamespaceLimits[i]
+		if !(&a).Equal(&b) {
+			return false
+		}
+	}
+	if !this.OveragePolicy.Equal(that1.OveragePolicy) {
+		return false
+	}
+	if !this.Binding.Equal(that1.Binding) {
+		return false
+	}
+	if this.ActivationRequired != that1.ActivationRequired {
+		return false
+	}
+	if this.Trial != that1.Trial {
+		return false
+	}
+	if this.AddOn != that1.AddOn {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *FeatureList) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FeatureList)
+	if !ok {
+		that2, ok := that.(FeatureList)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Features) != len(that1.Features) {
+		return false
+	}
+	for i := range this.Features {
+		if this.Features[i] != that1.Features[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *SignatureOptions) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SignatureOptions)
+	if !ok {
+		that2, ok := that.(SignatureOptions)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Algorithm != that1.Algorithm {
+		return false
+	}
+	if this.HashAlgorithm != that1.HashAlgorithm {
+		return false
+	}
+	if this.SaltLength != that1.SaltLength {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *NamespaceLimit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*NamespaceLimit)
+	if !ok {
+		that2, ok := that.(NamespaceLimit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.EntityLimit != that1.EntityLimit {
+		return false
+	}
+	if len(this.EntityClassLimits) != len(that1.EntityClassLimits) {
+		return false
+	}
+	for i := range this.EntityClassLimits {
+		if this.EntityClassLimits[i] != that1.EntityClassLimits[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *OveragePolicy) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*OveragePolicy)
+	if !ok {
+		that2, ok := that.(OveragePolicy)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Mode != that1.Mode {
+		return false
+	}
+	if this.BurstPercent != that1.BurstPercent {
+		return false
+	}
+	if this.BurstDuration != that1.BurstDuration {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *Binding) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Binding)
+	if !ok {
+		that2, ok := that.(Binding)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ClusterID != that1.ClusterID {
+		return false
+	}
+	if len(this.Fingerprints) != len(that1.Fingerprints) {
+		return false
+	}
+	for i := range this.Fingerprints {
+		if this.Fingerprints[i] != that1.Fingerprints[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (m *LicenseFile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.License.Size()
+	n += 1 + l + sovLicense(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = m.Metadata.Size()
+	n += 1 + l + sovLicense(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *License) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovLicense(uint64(m.Version))
+	}
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = len(m.AccountName)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.AccountID != 0 {
+		n += 1 + sovLicense(uint64(m.AccountID))
+	}
+	l = len(m.Issued)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = len(m.ValidUntil)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = len(m.Plan)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.Features != nil {
+		l = m.Features.Size()
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = m.SignatureOptions.Size()
+	n += 1 + l + sovLicense(uint64(l))
+	if m.EntityLimit != 0 {
+		n += 1 + sovLicense(uint64(m.EntityLimit))
+	}
+	if m.AllowTessenOptOut {
+		n += 2
+	}
+	if len(m.EntityClassLimits) > 0 {
+		for k, v := range m.EntityClassLimits {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLicense(uint64(len(k))) + 1 + sovLicense(uint64(v))
+			n += mapEntrySize + 1 + sovLicense(uint64(mapEntrySize))
+		}
+	}
+	if len(m.NamespaceLimits) > 0 {
+		for k, v := range m.NamespaceLimits {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovLicense(uint64(len(k))) + 1 + l + sovLicense(uint64(l))
+			n += mapEntrySize + 1 + sovLicense(uint64(mapEntrySize))
+		}
+	}
+	if m.OveragePolicy != nil {
+		l = m.OveragePolicy.Size()
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.Binding != nil {
+		l = m.Binding.Size()
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.ActivationRequired {
+		n += 3
+	}
+	if m.Trial {
+		n += 3
+	}
+	if m.AddOn {
+		n += 3
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovLicense(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignatureOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Algorithm)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	l = len(m.HashAlgorithm)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.SaltLength != 0 {
+		n += 1 + sovLicense(uint64(m.SaltLength))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NamespaceLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EntityLimit != 0 {
+		n += 1 + sovLicense(uint64(m.EntityLimit))
+	}
+	if len(m.EntityClassLimits) > 0 {
+		for k, v := range m.EntityClassLimits {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLicense(uint64(len(k))) + 1 + sovLicense(uint64(v))
+			n += mapEntrySize + 1 + sovLicense(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OveragePolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Mode)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if m.BurstPercent != 0 {
+		n += 1 + sovLicense(uint64(m.BurstPercent))
+	}
+	if m.BurstDuration != 0 {
+		n += 1 + sovLicense(uint64(m.BurstDuration))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Binding) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClusterID)
+	if l > 0 {
+		n += 1 + l + sovLicense(uint64(l))
+	}
+	if len(m.Fingerprints) > 0 {
+		for _, s := range m.Fingerprints {
+			l = len(s)
+			n += 1 + l + sovLicense(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovLicense(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLicense(x uint64) (n int) {
+	return sovLicense(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *LicenseFile) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LicenseFile: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LicenseFile: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field License", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.License.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *License) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: License: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: License: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issuer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccountName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountID", wireType)
+			}
+			m.AccountID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issued", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issued = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidUntil = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Plan", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Plan = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Features == nil {
+				m.Features = &FeatureList{}
+			}
+			if err := m.Features.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SignatureOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityLimit", wireType)
+			}
+			m.EntityLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntityLimit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowTessenOptOut", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowTessenOptOut = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityClassLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EntityClassLimits == nil {
+				m.EntityClassLimits = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLicense
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLicense(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EntityClassLimits[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NamespaceLimits == nil {
+				m.NamespaceLimits = make(map[string]NamespaceLimit)
+			}
+			var mapkey string
+			mapvalue := &NamespaceLimit{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLicense
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthLicense
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &NamespaceLimit{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLicense(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.NamespaceLimits[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OveragePolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OveragePolicy == nil {
+				m.OveragePolicy = &OveragePolicy{}
+			}
+			if err := m.OveragePolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Binding", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Binding == nil {
+				m.Binding = &Binding{}
+			}
+			if err := m.Binding.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationRequired", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ActivationRequired = bool(v != 0)
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trial", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Trial = bool(v != 0)
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddOn", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AddOn = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignatureOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignatureOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignatureOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HashAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SaltLength", wireType)
+			}
+			m.SaltLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SaltLength |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityLimit", wireType)
+			}
+			m.EntityLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntityLimit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityClassLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EntityClassLimits == nil {
+				m.EntityClassLimits = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLicense
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLicense
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLicense(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLicense
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EntityClassLimits[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OveragePolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OveragePolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OveragePolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstPercent", wireType)
+			}
+			m.BurstPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstPercent |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstDuration", wireType)
+			}
+			m.BurstDuration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstDuration |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Binding) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Binding: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Binding: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClusterID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fingerprints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLicense
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fingerprints = append(m.Fingerprints, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLicense(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLicense
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLicense(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLicense
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLicense
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLicense
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLicense
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLicense
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthLicense        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLicense          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLicense = fmt.Errorf("proto: unexpected end of group")
+)
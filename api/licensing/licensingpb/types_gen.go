@@ -0,0 +1,12 @@
+package licensingpb
+
+// The messages are generated with protoc 3.19.4 and protoc-gen-gofast from
+// github.com/gogo/protobuf v1.3.2, as the sensu/core types. go build builds
+// protoc-gen-gofast at the version required by go.mod. The import paths of
+// license.proto require this repository and sensu/core to be checked out in
+// $GOPATH/src.
+
+//go:generate go run ../../../scripts/check_protoc
+//go:generate go build -o $GOPATH/bin/protoc-gen-gofast github.com/gogo/protobuf/protoc-gen-gofast
+//go:generate -command protoc protoc --plugin $GOPATH/bin/protoc-gen-gofast --gofast_out=plugins:$GOPATH/src -I=$GOPATH/pkg/mod -I=$GOPATH/src -I=$GOPATH/pkg/mod/github.com/gogo/protobuf@v1.3.2/protobuf
+//go:generate protoc github.com/sensu/sensu-licensing/v2/api/licensing/licensingpb/license.proto
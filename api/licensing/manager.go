@@ -0,0 +1,419 @@
+package licensing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultExpiryWarning is the default period before the expiry of a license
+// during which it is reported as expiring soon.
+const DefaultExpiryWarning = 30 * 24 * time.Hour
+
+const (
+	// maxRecheckInterval is the longest a Manager waits before checking the
+	// state of the license again, which also applies when there is no
+	// license.
+	maxRecheckInterval = 24 * time.Hour
+	// minWatchBackoff and maxWatchBackoff bound the delay before a Manager
+	// watches the license again after its watch failed.
+	minWatchBackoff = 100 * time.Millisecond
+	maxWatchBackoff = 30 * time.Second
+)
+
+// LicenseState is the state of the license managed by a Manager.
+type LicenseState string
+
+const (
+	// StateNone means there is no license in the store.
+	StateNone LicenseState = "none"
+	// StateValid means the license is valid.
+	StateValid LicenseState = "valid"
+	// StateExpiringSoon means the license is valid but expires within the
+	// expiry warning period.
+	StateExpiringSoon LicenseState = "expiring_soon"
+	// StateGrace means the license has expired but is within its grace
+	// period.
+	StateGrace LicenseState = "grace"
+	// StateExpired means the license has expired and its grace period is
+	// over.
+	StateExpired LicenseState = "expired"
+	// StateInvalid means the license failed validation for a reason other
+	// than its expiry.
+	StateInvalid LicenseState = "invalid"
+)
+
+// EventType is the type of a license event published by a Manager.
+type EventType string
+
+const (
+	// EventApplied is published when a new valid license is loaded, or when
+	// the add-ons combined with the valid license change.
+	EventApplied EventType = "applied"
+	// EventExpiringSoon is published when the license enters its expiry
+	// warning period.
+	EventExpiringSoon EventType = "expiring_soon"
+	// EventEnteredGrace is published when the license expires but enters its
+	// grace period.
+	EventEnteredGrace EventType = "entered_grace"
+	// EventExpired is published when the license expires for good.
+	EventExpired EventType = "expired"
+	// EventInvalid is published when an invalid license is loaded.
+	EventInvalid EventType = "invalid"
+	// EventRemoved is published when the license is removed from the store.
+	EventRemoved EventType = "removed"
+)
+
+// stateEvents maps license states to the event published when the license
+// enters them.
+var stateEvents = map[LicenseState]EventType{
+	StateNone:         EventRemoved,
+	StateExpiringSoon: EventExpiringSoon,
+	StateGrace:        EventEnteredGrace,
+	StateExpired:      EventExpired,
+	StateInvalid:      EventInvalid,
+}
+
+// LicenseStatus is the cached result of the validation of the managed license.
+type LicenseStatus struct {
+	// State is the state of the license.
+	State LicenseState
+	// LicenseFile is the license file, which is nil in the StateNone state.
+	LicenseFile *LicenseFile
+	// Err is the validation error of the license, if any.
+	Err error
+	// Entitlements are the entitlements of the license combined with its
+	// add-ons, unless the license is invalid or missing.
+	Entitlements *Entitlements
+	// AddOns are the add-on license files combined with the license, which
+	// have not expired.
+	AddOns []*LicenseFile
+	// AddOnErrors are the validation errors of the add-on license files left
+	// out of the entitlements, by add-on license ID.
+	AddOnErrors map[string]error
+}
+
+// Event is a change of the managed license.
+type Event struct {
+	// Type is the type of the event.
+	Type EventType
+	// Status is the status of the license after the change.
+	Status LicenseStatus
+}
+
+// Manager loads the license from a store and keeps its validation result up
+// to date as the license is changed in the store, and as it reaches its
+// expiry. Changes are published to subscribers.
+type Manager struct {
+	// ExpiryWarning is the period before the expiry of the license during
+	// which it is reported as expiring soon.
+	ExpiryWarning time.Duration
+	// GracePeriod is the period after the expiry of the license during which
+	// it is reported as in grace rather than expired.
+	GracePeriod time.Duration
+
+	store     Store
+	validator *Validator
+
+	// now returns the current time, which determines the expiry of the
+	// license.
+	now func() time.Time
+
+	mu          sync.Mutex
+	status      LicenseStatus
+	subscribers map[*eventQueue[Event]]struct{}
+}
+
+// NewManager creates a Manager for the license held in the given store.
+// Licenses are validated with the given validator, or with the default one
+// when nil.
+func NewManager(store Store, validator *Validator) *Manager {
+	if validator == nil {
+		validator = new(Validator)
+	}
+	return &Manager{
+		ExpiryWarning: DefaultExpiryWarning,
+		store:         store,
+		validator:     validator,
+		now:           time.Now,
+		status:        LicenseStatus{State: StateNone},
+		subscribers:   make(map[*eventQueue[Event]]struct{}),
+	}
+}
+
+// Start loads and validates the license, and keeps it up to date in the
+// background until ctx is done. The add-ons of the license are loaded again
+// whenever they change.
+func (m *Manager) Start(ctx context.Context) error {
+	// watch before loading so that no change is missed in between. Both the
+	// license and its add-ons are under the prefix of the licensing API.
+	watchCtx, cancel := context.WithCancel(ctx)
+	changes := m.store.Watch(watchCtx, licensingKeyPrefix())
+	if err := m.load(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	go func() {
+		defer func() { cancel() }()
+		timer := time.NewTimer(m.untilNextBoundary())
+		defer timer.Stop()
+		// rewatch is set while waiting to watch again after a failed watch
+		var rewatch <-chan time.Time
+		backoff := minWatchBackoff
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-rewatch:
+				// watch again and reload in case a change was missed
+				rewatch = nil
+				watchCtx, cancel = context.WithCancel(ctx)
+				changes = m.store.Watch(watchCtx, licensingKeyPrefix())
+				_ = m.load(ctx)
+			case event, ok := <-changes:
+				if !ok {
+					// the watch failed, back off before watching again so
+					// that a store failing every watch is not hammered
+					cancel()
+					changes = nil
+					rewatch = time.After(backoff)
+					backoff *= 2
+					if backoff > maxWatchBackoff {
+						backoff = maxWatchBackoff
+					}
+				} else if event.Type == WatchError {
+					// the channel is closed right after the error
+				} else if event.Entry.Key == AddOnsKey() {
+					backoff = minWatchBackoff
+					if file := m.Status().LicenseFile; file != nil {
+						m.update(ctx, file)
+					}
+				} else if event.Entry.Key != LicenseKey() {
+					// other licensing resources do not affect the license
+					continue
+				} else {
+					backoff = minWatchBackoff
+					if event.Type == WatchDelete {
+						m.update(ctx, nil)
+					} else {
+						file := &LicenseFile{}
+						if err := json.Unmarshal(event.Entry.Value, file); err != nil {
+							m.setStatus(LicenseStatus{State: StateInvalid, Err: err}, true)
+						} else {
+							m.update(ctx, file)
+						}
+					}
+				}
+			case <-timer.C:
+				if file := m.Status().LicenseFile; file != nil {
+					m.update(ctx, file)
+				}
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(m.untilNextBoundary())
+		}
+	}()
+	return nil
+}
+
+// Status returns the cached status of the license.
+func (m *Manager) Status() LicenseStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// Subscribe returns a channel receiving the license events, until ctx is
+// done.
+func (m *Manager) Subscribe(ctx context.Context) <-chan Event {
+	queue, ch := newEventQueue[Event](ctx)
+	m.mu.Lock()
+	m.subscribers[queue] = struct{}{}
+	m.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		delete(m.subscribers, queue)
+		m.mu.Unlock()
+	}()
+	return ch
+}
+
+// load reads the license from the store and updates its status.
+func (m *Manager) load(ctx context.Context) error {
+	file, err := GetLicenseFile(ctx, m.store)
+	if errors.Is(err, ErrNotFound) {
+		m.update(ctx, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m.update(ctx, file)
+	return nil
+}
+
+// update validates the license file and updates its status.
+func (m *Manager) update(ctx context.Context, file *LicenseFile) {
+	previous := m.Status()
+	status := m.evaluate(ctx, file)
+	changed := (previous.LicenseFile == nil) != (file == nil) ||
+		(file != nil && !bytes.Equal(previous.LicenseFile.Signature, file.Signature)) ||
+		!sameLicenseFiles(previous.AddOns, status.AddOns) ||
+		!sameAddOnErrors(previous.AddOnErrors, status.AddOnErrors)
+	m.setStatus(status, changed)
+}
+
+// sameAddOnErrors returns whether both maps report the same invalid add-ons.
+func sameAddOnErrors(a, b map[string]error) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sameLicenseFiles returns whether both lists hold the same license files.
+func sameLicenseFiles(a, b []*LicenseFile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].Signature, b[i].Signature) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate determines the status of the license file at the current time,
+// along with its entitlements. Trial license files are checked against the
+// TrialLedger of the store, so that a second trial written directly to the
+// store is invalid, and add-ons cannot be used as the license.
+func (m *Manager) evaluate(ctx context.Context, file *LicenseFile) LicenseStatus {
+	if file == nil {
+		return LicenseStatus{State: StateNone}
+	}
+	status := LicenseStatus{LicenseFile: file}
+	if file.License.AddOn {
+		status.State = StateInvalid
+		status.Err = errBaseAddOn
+		return status
+	}
+
+	// the validator reports the expiry last, which means the license is
+	// otherwise valid. The expiry itself is determined by the manager clock.
+	if err := m.validator.Validate(file); err != nil && !errors.Is(err, ErrExpired) {
+		status.State = StateInvalid
+		status.Err = err
+		return status
+	}
+	// the trial is only recorded when applied through a History, and a
+	// ledger that cannot be read does not invalidate the trial
+	if file.License.Trial {
+		if err := NewTrialLedger(m.store).Check(ctx, file); errors.Is(err, ErrTrialUsed) {
+			status.State = StateInvalid
+			status.Err = err
+			return status
+		}
+	}
+
+	now := m.now()
+	entitlements, addOns, addOnErrors, err := loadAddOns(ctx, m.store, m.validator, file, now)
+	if err != nil {
+		status.State = StateInvalid
+		status.Err = err
+		return status
+	}
+	status.Entitlements = entitlements
+	status.AddOns = addOns
+	status.AddOnErrors = addOnErrors
+
+	validUntil := time.Time(file.License.ValidUntil)
+	switch {
+	case now.After(validUntil.Add(m.GracePeriod)):
+		status.State = StateExpired
+		status.Err = ErrExpired
+	case now.After(validUntil):
+		status.State = StateGrace
+		status.Err = ErrExpired
+	case now.After(validUntil.Add(-m.ExpiryWarning)):
+		status.State = StateExpiringSoon
+	default:
+		status.State = StateValid
+	}
+	return status
+}
+
+// setStatus caches the status and publishes the events of the transition
+// from the previous status.
+func (m *Manager) setStatus(status LicenseStatus, changed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous := m.status
+	m.status = status
+
+	var events []EventType
+	if changed && (status.State == StateValid || status.State == StateExpiringSoon) {
+		events = append(events, EventApplied)
+	}
+	if eventType, ok := stateEvents[status.State]; ok && (changed || status.State != previous.State) {
+		events = append(events, eventType)
+	}
+	for _, eventType := range events {
+		for queue := range m.subscribers {
+			queue.push(Event{Type: eventType, Status: status})
+		}
+	}
+}
+
+// untilNextBoundary returns the duration until the next time at which the
+// state of the license, or its entitlements as its add-ons expire, change on
+// their own, up to maxRecheckInterval.
+func (m *Manager) untilNextBoundary() time.Duration {
+	status := m.Status()
+
+	// without a license, only changes to the store matter
+	if status.LicenseFile == nil {
+		return maxRecheckInterval
+	}
+
+	now := m.now()
+	validUntil := time.Time(status.LicenseFile.License.ValidUntil)
+	boundaries := []time.Time{
+		validUntil.Add(-m.ExpiryWarning),
+		validUntil,
+		validUntil.Add(m.GracePeriod),
+	}
+	for _, addOn := range status.AddOns {
+		boundaries = append(boundaries, time.Time(addOn.License.ValidUntil))
+	}
+	next := maxRecheckInterval
+	for _, boundary := range boundaries {
+		if !boundary.After(now) {
+			continue
+		}
+		// boundaries centuries away saturate the duration, so compare it
+		// before adding to it
+		wait := boundary.Sub(now)
+		if wait >= next {
+			continue
+		}
+		// wake up just after the boundary so that the state has changed
+		next = wait + time.Millisecond
+	}
+	return next
+}
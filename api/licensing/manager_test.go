@@ -0,0 +1,257 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nextLicenseEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for license event")
+	}
+	return Event{}
+}
+
+func TestManagerEvaluate(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(NewMemoryStore(), &Validator{PublicKey: testPublicKey})
+	manager.ExpiryWarning = 24 * time.Hour
+	manager.GracePeriod = 24 * time.Hour
+	manager.now = func() time.Time { return now }
+
+	tests := []struct {
+		name       string
+		validUntil time.Time
+		want       LicenseState
+	}{
+		{name: "valid", validUntil: now.Add(48 * time.Hour), want: StateValid},
+		{name: "expiring soon", validUntil: now.Add(time.Hour), want: StateExpiringSoon},
+		{name: "grace", validUntil: now.Add(-time.Hour), want: StateGrace},
+		{name: "expired", validUntil: now.Add(-48 * time.Hour), want: StateExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := signedMockLicenseFile(t, "Acme Corp.", tt.validUntil)
+			assert.Equal(t, tt.want, manager.evaluate(ctx, file).State)
+		})
+	}
+
+	file := signedMockLicenseFile(t, "Acme Corp.", now.Add(48*time.Hour))
+	file.License.EntityLimit = 42
+	status := manager.evaluate(ctx, file)
+	assert.Equal(t, StateInvalid, status.State)
+	assert.Error(t, status.Err)
+
+	assert.Equal(t, StateNone, manager.evaluate(ctx, nil).State)
+}
+
+func TestManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+
+	first := signedMockLicenseFile(t, "first", now.Add(48*time.Hour))
+	if err := PutLicenseFile(ctx, store, first); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager(store, &Validator{PublicKey: testPublicKey})
+	manager.ExpiryWarning = 24 * time.Hour
+	events := manager.Subscribe(ctx)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	event := nextLicenseEvent(t, events)
+	assert.Equal(t, EventApplied, event.Type)
+	assert.Equal(t, StateValid, manager.Status().State)
+	assert.Equal(t, first.License, manager.Status().LicenseFile.License)
+
+	second := signedMockLicenseFile(t, "second", now.Add(time.Hour))
+	if err := PutLicenseFile(ctx, store, second); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventApplied, event.Type)
+	assert.Equal(t, "second", event.Status.LicenseFile.License.AccountName)
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventExpiringSoon, event.Type)
+
+	invalid := signedMockLicenseFile(t, "invalid", now.Add(48*time.Hour))
+	invalid.License.AccountName = "tampered"
+	if err := PutLicenseFile(ctx, store, invalid); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventInvalid, event.Type)
+	assert.Error(t, event.Status.Err)
+
+	if _, err := store.Put(ctx, LicenseKey(), []byte("{")); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventInvalid, event.Type)
+
+	if err := DeleteLicenseFile(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventRemoved, event.Type)
+	assert.Equal(t, StateNone, manager.Status().State)
+}
+
+func TestManagerAddOns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+
+	base := testMockLicenseFile()
+	base.License.ValidUntil = Timestamp(now.Add(48 * time.Hour))
+	base.License.EntityLimit = 100
+	if err := SignLicenseFile(base, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutLicenseFile(ctx, store, base); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager(store, &Validator{PublicKey: testPublicKey})
+	manager.ExpiryWarning = 0
+	events := manager.Subscribe(ctx)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, EventApplied, nextLicenseEvent(t, events).Type)
+	assert.Equal(t, 100, manager.Status().Entitlements.EntityLimit)
+
+	addOn := testMockLicenseFile()
+	addOn.License.AddOn = true
+	addOn.License.ValidUntil = Timestamp(now.Add(24 * time.Hour))
+	addOn.License.EntityLimit = 10
+	if err := SignLicenseFile(addOn, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutAddOnLicenseFiles(ctx, store, []*LicenseFile{addOn}); err != nil {
+		t.Fatal(err)
+	}
+	event := nextLicenseEvent(t, events)
+	assert.Equal(t, EventApplied, event.Type)
+	assert.Equal(t, 110, event.Status.Entitlements.EntityLimit)
+	assert.Len(t, event.Status.AddOns, 1)
+
+	// invalid add-ons are left out without invalidating the license
+	tampered := *addOn
+	tampered.License.EntityLimit = 1000
+	other := testMockLicenseFile()
+	other.License.AddOn = true
+	other.License.AccountID = 42
+	other.License.ValidUntil = addOn.License.ValidUntil
+	if err := SignLicenseFile(other, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutAddOnLicenseFiles(ctx, store, []*LicenseFile{addOn, &tampered, other}); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventApplied, event.Type)
+	assert.Equal(t, StateValid, event.Status.State)
+	assert.Equal(t, 110, event.Status.Entitlements.EntityLimit)
+	assert.Len(t, event.Status.AddOns, 1)
+	assert.Len(t, event.Status.AddOnErrors, 2)
+	assert.ErrorIs(t, event.Status.AddOnErrors[other.ID()], ErrAccountMismatch)
+
+	// add-ons cannot be used as the license
+	if err := PutLicenseFile(ctx, store, addOn); err != nil {
+		t.Fatal(err)
+	}
+	event = nextLicenseEvent(t, events)
+	assert.Equal(t, EventInvalid, event.Type)
+	assert.Nil(t, event.Status.Entitlements)
+}
+
+func TestManagerExpiry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+
+	// timestamps have a precision of one second, so this license expires
+	// within a second
+	file := signedMockLicenseFile(t, "Acme Corp.", time.Now().Add(time.Second).Truncate(time.Second))
+	value, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put(ctx, LicenseKey(), value); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager(store, &Validator{PublicKey: testPublicKey})
+	manager.ExpiryWarning = 0
+	events := manager.Subscribe(ctx)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, EventApplied, nextLicenseEvent(t, events).Type)
+	event := nextLicenseEvent(t, events)
+	assert.Equal(t, EventExpired, event.Type)
+	assert.ErrorIs(t, event.Status.Err, ErrExpired)
+	assert.Equal(t, StateExpired, manager.Status().State)
+}
+
+func TestManagerUntilNextBoundary(t *testing.T) {
+	manager := NewManager(NewMemoryStore(), &Validator{PublicKey: testPublicKey})
+	manager.now = func() time.Time { return now }
+	assert.Equal(t, maxRecheckInterval, manager.untilNextBoundary())
+
+	// boundaries further than time.Duration can represent
+	file := signedMockLicenseFile(t, "Acme Corp.", time.Date(9999, 12, 4, 0, 0, 0, 0, time.UTC))
+	manager.update(context.Background(), file)
+	assert.Equal(t, maxRecheckInterval, manager.untilNextBoundary())
+
+	file = signedMockLicenseFile(t, "Acme Corp.", now.Add(time.Hour))
+	manager.ExpiryWarning = 0
+	manager.update(context.Background(), file)
+	assert.Equal(t, time.Hour+time.Millisecond, manager.untilNextBoundary())
+}
+
+// failingWatchStore is a Store whose watches always fail at once.
+type failingWatchStore struct {
+	*MemoryStore
+
+	mu      sync.Mutex
+	watches int
+}
+
+func (s *failingWatchStore) Watch(ctx context.Context, key string) <-chan WatchEvent {
+	s.mu.Lock()
+	s.watches++
+	s.mu.Unlock()
+	ch := make(chan WatchEvent)
+	close(ch)
+	return ch
+}
+
+func TestManagerWatchBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := &failingWatchStore{MemoryStore: NewMemoryStore()}
+
+	manager := NewManager(store, &Validator{PublicKey: testPublicKey})
+	if err := manager.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(250 * time.Millisecond)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	// the initial watch, then after 100ms and 300ms at the earliest
+	assert.LessOrEqual(t, store.watches, 3, "failed watches should be retried with a backoff")
+}
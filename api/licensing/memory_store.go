@@ -0,0 +1,137 @@
+package licensing
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is a Store keeping its entries in memory, mostly useful for
+// testing.
+type MemoryStore struct {
+	mu       sync.Mutex
+	entries  map[string]*Entry
+	revision int64
+	watchers map[*memoryWatcher]struct{}
+}
+
+type memoryWatcher struct {
+	key   string
+	queue *eventQueue[WatchEvent]
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:  make(map[string]*Entry),
+		watchers: make(map[*memoryWatcher]struct{}),
+	}
+}
+
+// Get implements the Store interface.
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyEntry(entry), nil
+}
+
+// Put implements the Store interface.
+func (s *MemoryStore) Put(ctx context.Context, key string, value []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(key, value), nil
+}
+
+// CompareAndSwap implements the Store interface.
+func (s *MemoryStore) CompareAndSwap(ctx context.Context, key string, value []byte, revision int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var current int64
+	if entry, ok := s.entries[key]; ok {
+		current = entry.Revision
+	}
+	if current != revision {
+		return 0, ErrRevisionMismatch
+	}
+	return s.put(key, value), nil
+}
+
+// put must be called with the lock held.
+func (s *MemoryStore) put(key string, value []byte) int64 {
+	s.revision++
+	entry := &Entry{
+		Key:      key,
+		Value:    append([]byte(nil), value...),
+		Revision: s.revision,
+	}
+	s.entries[key] = entry
+	s.notify(WatchEvent{Type: WatchPut, Entry: entry})
+	return entry.Revision
+}
+
+// Delete implements the Store interface.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.entries, key)
+	s.revision++
+	s.notify(WatchEvent{Type: WatchDelete, Entry: &Entry{Key: key, Revision: s.revision}})
+	return nil
+}
+
+// List implements the Store interface.
+func (s *MemoryStore) List(ctx context.Context, prefix string) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []*Entry
+	for key, entry := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			entries = append(entries, copyEntry(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// Watch implements the Store interface.
+func (s *MemoryStore) Watch(ctx context.Context, key string) <-chan WatchEvent {
+	queue, ch := newEventQueue[WatchEvent](ctx)
+	watcher := &memoryWatcher{key: key, queue: queue}
+
+	s.mu.Lock()
+	s.watchers[watcher] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.watchers, watcher)
+		s.mu.Unlock()
+	}()
+	return ch
+}
+
+// notify must be called with the lock held.
+func (s *MemoryStore) notify(event WatchEvent) {
+	for watcher := range s.watchers {
+		if watchesKey(watcher.key, event.Entry.Key) {
+			watcher.queue.push(WatchEvent{Type: event.Type, Entry: copyEntry(event.Entry)})
+		}
+	}
+}
+
+func copyEntry(entry *Entry) *Entry {
+	c := *entry
+	if entry.Value != nil {
+		c.Value = append([]byte(nil), entry.Value...)
+	}
+	return &c
+}
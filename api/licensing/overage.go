@@ -0,0 +1,71 @@
+package licensing
+
+import (
+	"fmt"
+	"time"
+)
+
+// OverageMode determines how entities are admitted beyond the entity limits
+// of a license.
+type OverageMode string
+
+const (
+	// OverageBlock denies entities beyond the limits. It is the mode of
+	// licenses without an overage policy.
+	OverageBlock OverageMode = "block"
+	// OverageWarn admits entities beyond the limits, reporting the overage.
+	OverageWarn OverageMode = "warn"
+	// OverageBurst admits entities beyond the limits, up to a percentage of
+	// the limits and for a bounded duration, so that temporary spikes are
+	// tolerated but sustained overuse is not.
+	OverageBurst OverageMode = "burst"
+)
+
+// OveragePolicy is the policy applied to entities beyond the entity limits of
+// a license.
+type OveragePolicy struct {
+	// Mode is the overage mode.
+	Mode OverageMode `json:"mode" yaml:"mode"`
+	// BurstPercent is the percentage of a limit that entities may exceed it by
+	// in the burst mode.
+	BurstPercent int `json:"burstPercent,omitempty" yaml:"burstPercent,omitempty"`
+	// BurstDuration is the maximum duration of a burst above a limit in the
+	// burst mode.
+	BurstDuration Duration `json:"burstDuration,omitempty" yaml:"burstDuration,omitempty"`
+}
+
+// Validate returns an error if the overage policy is invalid. A nil policy is
+// valid.
+func (p *OveragePolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	switch p.Mode {
+	case OverageBlock, OverageWarn:
+		if p.BurstPercent != 0 || p.BurstDuration != 0 {
+			return fmt.Errorf("overage mode %q does not allow bursts", p.Mode)
+		}
+	case OverageBurst:
+		if p.BurstPercent <= 0 {
+			return fmt.Errorf("overage burst percent must be positive: %d", p.BurstPercent)
+		}
+		if p.BurstDuration <= 0 {
+			return fmt.Errorf("overage burst duration must be positive: %s", p.BurstDuration)
+		}
+	default:
+		return fmt.Errorf("unsupported overage mode: %q", p.Mode)
+	}
+	return nil
+}
+
+// burstLimit returns the limit up to which entities are admitted during a
+// burst above the given limit.
+func (p *OveragePolicy) burstLimit(limit int) int {
+	// round up so that small limits still allow a burst
+	return limit + (limit*p.BurstPercent+99)/100
+}
+
+// burstDuration returns the maximum duration of a burst.
+func (p *OveragePolicy) burstDuration() time.Duration {
+	return time.Duration(p.BurstDuration)
+}
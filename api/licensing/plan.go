@@ -0,0 +1,119 @@
+package licensing
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AllFeatures is the feature name that enables every feature.
+const AllFeatures = "all"
+
+// ErrUnknownPlan means the license plan is not part of the plan catalog.
+var ErrUnknownPlan = errors.New("unknown plan")
+
+// Plan defines the entitlements included in a subscription plan, and which of
+// them may be overridden by individual licenses.
+type Plan struct {
+	// Features are the features included in the plan.
+	Features FeatureList `json:"features,omitempty" yaml:"features,omitempty"`
+	// OptionalFeatures are features that may be added to licenses on the plan.
+	OptionalFeatures FeatureList `json:"optionalFeatures,omitempty" yaml:"optionalFeatures,omitempty"`
+	// EntityLimit is the default limit of the total number of entities.
+	EntityLimit int `json:"entityLimit,omitempty" yaml:"entityLimit,omitempty"`
+	// MaxEntityLimit is the highest entity limit a license on the plan may be
+	// granted. When zero, the entity limit and the entity class limits of the
+	// plan cannot be overridden.
+	MaxEntityLimit int `json:"maxEntityLimit,omitempty" yaml:"maxEntityLimit,omitempty"`
+	// EntityClassLimits are the default limits of entities per entity class.
+	EntityClassLimits map[string]int `json:"entityClassLimits,omitempty" yaml:"entityClassLimits,omitempty"`
+	// AllowTessenOptOut indicates whether licenses on the plan may allow
+	// opting out of Tessen.
+	AllowTessenOptOut bool `json:"allowTessenOptOut,omitempty" yaml:"allowTessenOptOut,omitempty"`
+	// Duration is the default validity period of licenses on the plan.
+	Duration Duration `json:"duration" yaml:"duration"`
+}
+
+// PlanCatalog maps plan names to their definition.
+type PlanCatalog map[string]Plan
+
+// ParsePlanCatalog parses a YAML (or JSON) plan catalog, keyed by plan name.
+func ParsePlanCatalog(data []byte) (PlanCatalog, error) {
+	var catalog PlanCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("cannot parse plan catalog: %s", err)
+	}
+	return catalog, nil
+}
+
+// Plan returns the plan with the given name.
+func (c PlanCatalog) Plan(name string) (Plan, error) {
+	plan, ok := c[name]
+	if !ok {
+		return Plan{}, fmt.Errorf("%w: %q", ErrUnknownPlan, name)
+	}
+	return plan, nil
+}
+
+// ValidateLicense checks that the explicit fields of the license are either
+// the defaults of its plan or an override allowed by the plan.
+func (c PlanCatalog) ValidateLicense(license *License) error {
+	plan, err := c.Plan(license.Plan)
+	if err != nil {
+		return err
+	}
+
+	for _, feature := range license.Features {
+		if !plan.allowsFeature(feature) {
+			return fmt.Errorf("feature %q is not available on plan %q", feature, license.Plan)
+		}
+	}
+
+	if license.EntityLimit != plan.EntityLimit {
+		if plan.MaxEntityLimit == 0 {
+			return fmt.Errorf("plan %q does not allow overriding the entity limit", license.Plan)
+		}
+		if license.EntityLimit == 0 {
+			return fmt.Errorf("unlimited entities exceed the maximum entity limit of plan %q: %d", license.Plan, plan.MaxEntityLimit)
+		}
+		if license.EntityLimit > plan.MaxEntityLimit {
+			return fmt.Errorf("entity limit exceeds the maximum of plan %q: %d > %d", license.Plan, license.EntityLimit, plan.MaxEntityLimit)
+		}
+	}
+
+	for entityClass, limit := range license.EntityClassLimits {
+		if defaultLimit, ok := plan.EntityClassLimits[entityClass]; ok && limit == defaultLimit {
+			continue
+		}
+		if plan.MaxEntityLimit == 0 {
+			return fmt.Errorf("plan %q does not allow overriding the %s entity class limit", license.Plan, entityClass)
+		}
+		if limit == 0 {
+			return fmt.Errorf("unlimited %s entities exceed the maximum entity limit of plan %q: %d", entityClass, license.Plan, plan.MaxEntityLimit)
+		}
+		if limit > plan.MaxEntityLimit {
+			return fmt.Errorf("%s entity class limit exceeds the maximum of plan %q: %d > %d", entityClass, license.Plan, limit, plan.MaxEntityLimit)
+		}
+	}
+	if plan.MaxEntityLimit == 0 && len(license.EntityClassLimits) != len(plan.EntityClassLimits) {
+		return fmt.Errorf("plan %q does not allow overriding the entity class limits", license.Plan)
+	}
+
+	if license.AllowTessenOptOut && !plan.AllowTessenOptOut {
+		return fmt.Errorf("plan %q does not allow opting out of Tessen", license.Plan)
+	}
+	return nil
+}
+
+// allowsFeature returns whether a license on the plan may enable the feature.
+func (p Plan) allowsFeature(feature string) bool {
+	for _, features := range []FeatureList{p.Features, p.OptionalFeatures} {
+		for _, f := range features {
+			if f == feature || f == AllFeatures {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package licensing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanCatalogValidateLicense(t *testing.T) {
+	catalog := PlanCatalog{
+		"standard": Plan{
+			Features:          FeatureList{"rbac", "ldap"},
+			OptionalFeatures:  FeatureList{"federation"},
+			EntityLimit:       100,
+			EntityClassLimits: map[string]int{"agent": 80},
+		},
+		"enterprise": Plan{
+			Features:          FeatureList{AllFeatures},
+			EntityLimit:       1000,
+			MaxEntityLimit:    5000,
+			AllowTessenOptOut: true,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		license License
+		wantErr bool
+	}{
+		{
+			name: "plan defaults",
+			license: License{
+				Plan:              "standard",
+				Features:          FeatureList{"rbac", "ldap"},
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 80},
+			},
+		},
+		{
+			name: "optional feature",
+			license: License{
+				Plan:              "standard",
+				Features:          FeatureList{"rbac", "federation"},
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 80},
+			},
+		},
+		{
+			name: "unavailable feature",
+			license: License{
+				Plan:              "standard",
+				Features:          FeatureList{"all"},
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 80},
+			},
+			wantErr: true,
+		},
+		{
+			name: "entity limit override not allowed",
+			license: License{
+				Plan:              "standard",
+				EntityLimit:       200,
+				EntityClassLimits: map[string]int{"agent": 80},
+			},
+			wantErr: true,
+		},
+		{
+			name: "entity class limit override not allowed",
+			license: License{
+				Plan:              "standard",
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 80, "proxy": 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tessen opt-out not allowed",
+			license: License{
+				Plan:              "standard",
+				EntityLimit:       100,
+				EntityClassLimits: map[string]int{"agent": 80},
+				AllowTessenOptOut: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowed overrides",
+			license: License{
+				Plan:              "enterprise",
+				Features:          FeatureList{"anything"},
+				EntityLimit:       4000,
+				EntityClassLimits: map[string]int{"proxy": 1000},
+				AllowTessenOptOut: true,
+			},
+		},
+		{
+			name: "entity limit above maximum",
+			license: License{
+				Plan:        "enterprise",
+				EntityLimit: 6000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "entity class limit above maximum",
+			license: License{
+				Plan:              "enterprise",
+				EntityLimit:       1000,
+				EntityClassLimits: map[string]int{"agent": 6000},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unlimited entity class above maximum",
+			license: License{
+				Plan:              "enterprise",
+				EntityLimit:       1000,
+				EntityClassLimits: map[string]int{"agent": 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unlimited entities above maximum",
+			license: License{
+				Plan: "enterprise",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := catalog.ValidateLicense(&tt.license)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLicense() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := catalog.ValidateLicense(&License{Plan: "enterprise"})
+	if err == nil || err.Error() != `unlimited entities exceed the maximum entity limit of plan "enterprise": 5000` {
+		t.Errorf("unexpected error for unlimited entities: %v", err)
+	}
+
+	if err := catalog.ValidateLicense(&License{Plan: "missing"}); !errors.Is(err, ErrUnknownPlan) {
+		t.Errorf("expected ErrUnknownPlan, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package licensing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sensu/sensu-licensing/v2/api/licensing/licensingpb"
+)
+
+// ToProto converts the license file to its protobuf representation. The
+// license keeps the values of its JSON encoding, so that its signature can be
+// verified after the round trip.
+func (f *LicenseFile) ToProto() (*licensingpb.LicenseFile, error) {
+	license, err := f.License.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &licensingpb.LicenseFile{
+		License:   *license,
+		Signature: f.Signature,
+		Metadata:  f.ObjectMeta,
+	}, nil
+}
+
+// LicenseFileFromProto converts the protobuf representation of a license file
+// back to a license file.
+func LicenseFileFromProto(m *licensingpb.LicenseFile) (*LicenseFile, error) {
+	license, err := LicenseFromProto(&m.License)
+	if err != nil {
+		return nil, err
+	}
+	file := &LicenseFile{
+		License:    *license,
+		Signature:  m.Signature,
+		ObjectMeta: m.Metadata,
+	}
+	file.normalizeObjectMeta()
+	return file, nil
+}
+
+// MarshalProto encodes the license file in the protobuf wire format.
+func (f *LicenseFile) MarshalProto() ([]byte, error) {
+	m, err := f.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return m.Marshal()
+}
+
+// UnmarshalProto decodes the license file from the protobuf wire format.
+func (f *LicenseFile) UnmarshalProto(data []byte) error {
+	m := &licensingpb.LicenseFile{}
+	if err := m.Unmarshal(data); err != nil {
+		return err
+	}
+	file, err := LicenseFileFromProto(m)
+	if err != nil {
+		return err
+	}
+	*f = *file
+	return nil
+}
+
+// ToProto converts the license to its protobuf representation. Timestamps are
+// kept in TimestampFormat, with their UTC offset, and features are nil only
+// when the features of the license are.
+func (l *License) ToProto() (*licensingpb.License, error) {
+	options, err := l.SignatureOptions.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	m := &licensingpb.License{
+		Version:            int64(l.Version),
+		Issuer:             l.Issuer,
+		AccountName:        l.AccountName,
+		AccountID:          l.AccountID,
+		Issued:             time.Time(l.Issued).Format(TimestampFormat),
+		ValidUntil:         time.Time(l.ValidUntil).Format(TimestampFormat),
+		Plan:               l.Plan,
+		SignatureOptions:   *options,
+		EntityLimit:        int64(l.EntityLimit),
+		AllowTessenOptOut:  l.AllowTessenOptOut,
+		EntityClassLimits:  limitsToProto(l.EntityClassLimits),
+		ActivationRequired: l.ActivationRequired,
+		Trial:              l.Trial,
+		AddOn:              l.AddOn,
+	}
+	if l.Features != nil {
+		m.Features = &licensingpb.FeatureList{Features: l.Features}
+	}
+	if len(l.NamespaceLimits) > 0 {
+		m.NamespaceLimits = make(map[string]licensingpb.NamespaceLimit, len(l.NamespaceLimits))
+		for namespace, limits := range l.NamespaceLimits {
+			m.NamespaceLimits[namespace] = licensingpb.NamespaceLimit{
+				EntityLimit:       int64(limits.EntityLimit),
+				EntityClassLimits: limitsToProto(limits.EntityClassLimits),
+			}
+		}
+	}
+	if policy := l.OveragePolicy; policy != nil {
+		m.OveragePolicy = &licensingpb.OveragePolicy{
+			Mode:          string(policy.Mode),
+			BurstPercent:  int64(policy.BurstPercent),
+			BurstDuration: int64(policy.BurstDuration),
+		}
+	}
+	if binding := l.Binding; binding != nil {
+		m.Binding = &licensingpb.Binding{
+			ClusterID:    binding.ClusterID,
+			Fingerprints: binding.Fingerprints,
+		}
+	}
+	return m, nil
+}
+
+// LicenseFromProto converts the protobuf representation of a license back to
+// a license.
+func LicenseFromProto(m *licensingpb.License) (*License, error) {
+	options, err := SignatureOptionsFromProto(&m.SignatureOptions)
+	if err != nil {
+		return nil, err
+	}
+	issued, err := time.Parse(TimestampFormat, m.Issued)
+	if err != nil {
+		return nil, fmt.Errorf("invalid license issue time: %w", err)
+	}
+	validUntil, err := time.Parse(TimestampFormat, m.ValidUntil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expiration time: %w", err)
+	}
+	l := &License{
+		Version:            int(m.Version),
+		Issuer:             m.Issuer,
+		AccountName:        m.AccountName,
+		AccountID:          m.AccountID,
+		Issued:             Timestamp(issued),
+		ValidUntil:         Timestamp(validUntil),
+		Plan:               m.Plan,
+		SignatureOptions:   *options,
+		EntityLimit:        int(m.EntityLimit),
+		AllowTessenOptOut:  m.AllowTessenOptOut,
+		EntityClassLimits:  limitsFromProto(m.EntityClassLimits),
+		ActivationRequired: m.ActivationRequired,
+		Trial:              m.Trial,
+		AddOn:              m.AddOn,
+	}
+	if m.Features != nil {
+		// an empty list is decoded as nil, which would be encoded as null
+		l.Features = append(FeatureList{}, m.Features.Features...)
+	}
+	if len(m.NamespaceLimits) > 0 {
+		l.NamespaceLimits = make(map[string]NamespaceLimit, len(m.NamespaceLimits))
+		for namespace, limits := range m.NamespaceLimits {
+			l.NamespaceLimits[namespace] = NamespaceLimit{
+				EntityLimit:       int(limits.EntityLimit),
+				EntityClassLimits: limitsFromProto(limits.EntityClassLimits),
+			}
+		}
+	}
+	if policy := m.OveragePolicy; policy != nil {
+		l.OveragePolicy = &OveragePolicy{
+			Mode:          OverageMode(policy.Mode),
+			BurstPercent:  int(policy.BurstPercent),
+			BurstDuration: Duration(policy.BurstDuration),
+		}
+	}
+	if binding := m.Binding; binding != nil {
+		l.Binding = &Binding{
+			ClusterID:    binding.ClusterID,
+			Fingerprints: binding.Fingerprints,
+		}
+	}
+	return l, nil
+}
+
+// ToProto converts the signature options to their protobuf representation,
+// in which the hash algorithm is referred to by name.
+func (o *SignatureOptions) ToProto() (*licensingpb.SignatureOptions, error) {
+	hashName, err := o.Hash.name()
+	if err != nil {
+		return nil, err
+	}
+	return &licensingpb.SignatureOptions{
+		Algorithm:     o.Algorithm,
+		HashAlgorithm: hashName,
+		SaltLength:    int64(o.SaltLength),
+	}, nil
+}
+
+// SignatureOptionsFromProto converts the protobuf representation of signature
+// options back to signature options.
+func SignatureOptionsFromProto(m *licensingpb.SignatureOptions) (*SignatureOptions, error) {
+	hash, err := GetHashAlgorithm(m.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureOptions{
+		Algorithm:  m.Algorithm,
+		Hash:       hash,
+		SaltLength: int(m.SaltLength),
+	}, nil
+}
+
+// limitsToProto converts entity class limits to their protobuf
+// representation.
+func limitsToProto(limits map[string]int) map[string]int64 {
+	if len(limits) == 0 {
+		return nil
+	}
+	m := make(map[string]int64, len(limits))
+	for entityClass, limit := range limits {
+		m[entityClass] = int64(limit)
+	}
+	return m
+}
+
+// limitsFromProto converts the protobuf representation of entity class limits
+// back to entity class limits.
+func limitsFromProto(m map[string]int64) map[string]int {
+	if len(m) == 0 {
+		return nil
+	}
+	limits := make(map[string]int, len(m))
+	for entityClass, limit := range m {
+		limits[entityClass] = int(limit)
+	}
+	return limits
+}
@@ -0,0 +1,156 @@
+package licensing
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-licensing/v2/api/licensing/licensingpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseFileProtoRoundTrip(t *testing.T) {
+	file := goldenLicenseFile(t)
+
+	data, err := file.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &LicenseFile{}
+	if err := decoded.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file, decoded)
+	validator := &Validator{PublicKey: testPublicKey}
+	assert.NoError(t, validator.Validate(decoded))
+
+	assert.Error(t, decoded.UnmarshalProto([]byte{0xff}))
+}
+
+func TestLicenseProtoPreservesSignedJSON(t *testing.T) {
+	offset := time.FixedZone("", -4*60*60)
+	tests := []struct {
+		name    string
+		license License
+	}{
+		{
+			name: "null features",
+			license: License{
+				Version:          1,
+				Issued:           Timestamp(time.Date(2018, 7, 26, 12, 12, 6, 0, offset)),
+				ValidUntil:       Timestamp(time.Date(2019, 7, 26, 12, 12, 6, 0, offset)),
+				SignatureOptions: DefaultSignatureOptions,
+			},
+		},
+		{
+			name: "empty features",
+			license: License{
+				Version:          1,
+				Issued:           Timestamp(time.Date(2018, 7, 26, 12, 12, 6, 0, time.UTC)),
+				ValidUntil:       Timestamp(time.Date(2019, 7, 26, 12, 12, 6, 0, time.UTC)),
+				Features:         FeatureList{},
+				SignatureOptions: DefaultSignatureOptions,
+			},
+		},
+		{
+			name: "every field",
+			license: License{
+				Version:           1,
+				Issuer:            "Sensu, Inc.",
+				AccountName:       "Acme Corp.",
+				AccountID:         1<<63 + 1,
+				Issued:            Timestamp(time.Date(2018, 7, 26, 12, 12, 6, 0, offset)),
+				ValidUntil:        Timestamp(time.Date(2019, 7, 26, 12, 12, 6, 0, time.UTC)),
+				Plan:              "enterprise",
+				Features:          FeatureList{"rbac", "ldap"},
+				SignatureOptions:  DefaultSignatureOptions,
+				EntityLimit:       100,
+				AllowTessenOptOut: true,
+				EntityClassLimits: map[string]int{"agent": 80},
+				NamespaceLimits: map[string]NamespaceLimit{
+					"default": {EntityLimit: 10, EntityClassLimits: map[string]int{"agent": 5}},
+					"dev":     {EntityLimit: 5},
+				},
+				OveragePolicy: &OveragePolicy{
+					Mode:          OverageBurst,
+					BurstPercent:  10,
+					BurstDuration: Duration(72 * time.Hour),
+				},
+				Binding:            &Binding{ClusterID: "cluster", Fingerprints: []string{"a", "b"}},
+				ActivationRequired: true,
+				Trial:              true,
+				AddOn:              true,
+			},
+		},
+		{
+			name: "empty binding",
+			license: License{
+				Version:          1,
+				SignatureOptions: DefaultSignatureOptions,
+				Binding:          &Binding{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := json.Marshal(&tt.license)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := tt.license.ToProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := m.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := &licensingpb.License{}
+			if err := decoded.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, m.Equal(decoded))
+
+			license, err := LicenseFromProto(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := json.Marshal(license)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestSignatureOptionsProto(t *testing.T) {
+	m, err := DefaultSignatureOptions.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "SHA256", m.HashAlgorithm)
+	options, err := SignatureOptionsFromProto(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, DefaultSignatureOptions, *options)
+
+	unsupported := SignatureOptions{Algorithm: "PSS", Hash: HashAlgorithm(crypto.MD5)}
+	_, err = unsupported.ToProto()
+	assert.Error(t, err)
+	_, err = SignatureOptionsFromProto(&licensingpb.SignatureOptions{HashAlgorithm: "md5"})
+	assert.Error(t, err)
+}
+
+func TestLicenseFromProtoInvalidTimestamp(t *testing.T) {
+	m, err := (&License{SignatureOptions: DefaultSignatureOptions}).ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.ValidUntil = "tomorrow"
+	_, err = LicenseFromProto(m)
+	assert.Error(t, err)
+}
@@ -0,0 +1,383 @@
+package licensing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultSoftLimitRatio is the default fraction of a limit above which
+// entities are still admitted, but reported as exceeding the soft limit.
+const DefaultSoftLimitRatio = 0.9
+
+// Unlimited is the remaining capacity reported when no limit applies.
+const Unlimited = -1
+
+// LimitType distinguishes the limits reported by a quota.
+type LimitType string
+
+const (
+	// LimitNone means no limit was reached.
+	LimitNone LimitType = ""
+	// LimitSoft means the entity was admitted, but the usage is above the soft
+	// limit and the hard limit will soon be reached.
+	LimitSoft LimitType = "soft"
+	// LimitHard means the entity was denied because the hard limit was
+	// reached.
+	LimitHard LimitType = "hard"
+	// LimitOverage means the entity was admitted beyond the hard limit, as
+	// allowed by the OverageWarn mode.
+	LimitOverage LimitType = "overage"
+	// LimitBurst means the entity was admitted beyond the hard limit during a
+	// burst, as allowed by the OverageBurst mode.
+	LimitBurst LimitType = "burst"
+)
+
+// Admission is the answer of a quota to the admission of an entity.
+type Admission struct {
+	// Allowed indicates whether the entity can be admitted.
+	Allowed bool
+	// EntityClass is the class of the entity.
+	EntityClass string
+	// Remaining is the number of entities of the class that can still be
+	// admitted within the limits, before admitting this one, or Unlimited.
+	Remaining int
+	// Limit is the limit constraining the admission, either the total entity
+	// limit or the entity class limit of the license or of the namespace, or
+	// zero when there is none.
+	Limit int
+	// LimitType is the type of limit that was reached, if any.
+	LimitType LimitType
+	// BurstUntil is the time at which the current burst above the limit ends,
+	// when LimitType is LimitBurst.
+	BurstUntil time.Time
+	// Reason explains why the entity was denied, or why a limit was reported.
+	Reason string
+}
+
+// Quota enforces the entity limits of a license against the current number
+// of entities per entity class, and per entity class within the namespaces
+// the license limits. Entities beyond the limits are admitted according to
+// the overage policy of the license.
+type Quota struct {
+	// SoftLimitRatio is the fraction of a limit above which admissions are
+	// reported as exceeding the soft limit. Zero disables soft limits.
+	SoftLimitRatio float64
+
+	entityLimit       int
+	entityClassLimits map[string]int
+	namespaceLimits   map[string]NamespaceLimit
+	overagePolicy     *OveragePolicy
+
+	// now returns the current time, which determines the duration of bursts.
+	now func() time.Time
+
+	mu              sync.Mutex
+	counts          map[string]int
+	namespaceCounts map[string]map[string]int
+	// burstStarts are the times at which the bursts above the limits started,
+	// by limit name.
+	burstStarts map[string]time.Time
+}
+
+// quotaLimit is a limit checked by a quota.
+type quotaLimit struct {
+	name  string
+	value int
+	count int
+}
+
+// NewQuota creates a Quota enforcing the limits of the license file, given
+// the current number of entities per entity class.
+func NewQuota(file *LicenseFile, counts map[string]int) *Quota {
+	return NewEntitlementsQuota(&Entitlements{
+		EntityLimit:       file.EntityLimit(),
+		EntityClassLimits: file.EntityClassLimits(),
+		NamespaceLimits:   file.NamespaceLimits(),
+		OveragePolicy:     file.License.OveragePolicy,
+	}, counts)
+}
+
+// NewEntitlementsQuota creates a Quota enforcing the limits of the
+// entitlements of a base license and its add-ons, given the current number of
+// entities per entity class.
+func NewEntitlementsQuota(entitlements *Entitlements, counts map[string]int) *Quota {
+	q := &Quota{
+		SoftLimitRatio:    DefaultSoftLimitRatio,
+		entityLimit:       entitlements.EntityLimit,
+		entityClassLimits: entitlements.EntityClassLimits,
+		namespaceLimits:   entitlements.NamespaceLimits,
+		overagePolicy:     entitlements.OveragePolicy,
+		now:               time.Now,
+		counts:            make(map[string]int, len(counts)),
+		namespaceCounts:   make(map[string]map[string]int),
+		burstStarts:       make(map[string]time.Time),
+	}
+	for entityClass, count := range counts {
+		q.counts[entityClass] = count
+	}
+	return q
+}
+
+// SetCount updates the current number of entities of an entity class. The
+// bursts above the limits that the counts no longer exceed are over.
+func (q *Quota) SetCount(entityClass string, count int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.counts[entityClass] = count
+	q.endBursts()
+}
+
+// Count returns the current number of entities of an entity class.
+func (q *Quota) Count(entityClass string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.counts[entityClass]
+}
+
+// SetNamespaceCount updates the current number of entities of an entity
+// class within a namespace. The counts of the whole installation, set with
+// SetCount, must include the entities of every namespace.
+func (q *Quota) SetNamespaceCount(namespace, entityClass string, count int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	counts, ok := q.namespaceCounts[namespace]
+	if !ok {
+		counts = make(map[string]int)
+		q.namespaceCounts[namespace] = counts
+	}
+	counts[entityClass] = count
+	q.endBursts()
+}
+
+// NamespaceCount returns the current number of entities of an entity class
+// within a namespace.
+func (q *Quota) NamespaceCount(namespace, entityClass string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.namespaceCounts[namespace][entityClass]
+}
+
+// LoadNamespaceCounts sets the counts of the namespaces limited by the license
+// from the entity usage stored in the store. Namespaces without stored usage
+// have no entities.
+func (q *Quota) LoadNamespaceCounts(ctx context.Context, store Store) error {
+	for namespace := range q.namespaceLimits {
+		counts, err := GetEntityUsage(ctx, store, namespace)
+		if errors.Is(err, ErrNotFound) {
+			counts = nil
+		} else if err != nil {
+			return err
+		}
+		q.mu.Lock()
+		q.namespaceCounts[namespace] = make(map[string]int, len(counts))
+		for entityClass, count := range counts {
+			q.namespaceCounts[namespace][entityClass] = count
+		}
+		q.endBursts()
+		q.mu.Unlock()
+	}
+	return nil
+}
+
+// Admit answers whether one more entity of the given class can be admitted.
+// It does not change the entity counts, but an admission above a limit under
+// the burst overage policy starts the burst, which lasts until the counts set
+// afterwards are back under the limit.
+func (q *Quota) Admit(entityClass string) Admission {
+	return q.admit("", entityClass)
+}
+
+// AdmitInNamespace answers whether one more entity of the given class can be
+// admitted in the namespace, honoring the limits of the namespace in addition
+// to those of the license. It changes the same state as Admit.
+func (q *Quota) AdmitInNamespace(namespace, entityClass string) Admission {
+	return q.admit(namespace, entityClass)
+}
+
+// admit answers whether one more entity of the given class can be admitted in
+// the namespace, or in any namespace when empty.
+func (q *Quota) admit(namespace, entityClass string) Admission {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	admission := Admission{
+		Allowed:     true,
+		EntityClass: entityClass,
+		Remaining:   Unlimited,
+	}
+
+	// check the most constraining limit, the narrowest limit winning ties
+	limits := q.limits(namespace, entityClass)
+	var constraining *quotaLimit
+	for i := range limits {
+		remaining := limits[i].value - limits[i].count
+		if constraining == nil || remaining <= constraining.value-constraining.count {
+			constraining = &limits[i]
+		}
+	}
+	if constraining == nil {
+		return admission
+	}
+
+	admission.Limit = constraining.value
+	admission.Remaining = constraining.value - constraining.count
+	if admission.Remaining < 0 {
+		admission.Remaining = 0
+	}
+	if admission.Remaining == 0 {
+		return q.overLimits(admission, limits, *constraining)
+	}
+	if q.SoftLimitRatio > 0 && float64(constraining.count+1) > q.SoftLimitRatio*float64(constraining.value) {
+		admission.LimitType = LimitSoft
+		admission.Reason = fmt.Sprintf("approaching the %s: %d/%d", constraining.name, constraining.count+1, constraining.value)
+	}
+	return admission
+}
+
+// limits returns the limits applying to entities of the given class in the
+// namespace, or in any namespace when empty, from the broadest to the
+// narrowest. It must be called with the lock held.
+func (q *Quota) limits(namespace, entityClass string) []quotaLimit {
+	var limits []quotaLimit
+	if q.entityLimit > 0 {
+		limits = append(limits, quotaLimit{name: "entity limit", value: q.entityLimit, count: sum(q.counts)})
+	}
+	if classLimit, ok := q.entityClassLimits[entityClass]; ok {
+		limits = append(limits, quotaLimit{
+			name:  fmt.Sprintf("%s entity class limit", entityClass),
+			value: classLimit,
+			count: q.counts[entityClass],
+		})
+	}
+	if namespaceLimit, ok := q.namespaceLimits[namespace]; ok && namespace != "" {
+		counts := q.namespaceCounts[namespace]
+		if namespaceLimit.EntityLimit > 0 {
+			limits = append(limits, quotaLimit{
+				name:  fmt.Sprintf("entity limit of namespace %s", namespace),
+				value: namespaceLimit.EntityLimit,
+				count: sum(counts),
+			})
+		}
+		if classLimit, ok := namespaceLimit.EntityClassLimits[entityClass]; ok {
+			limits = append(limits, quotaLimit{
+				name:  fmt.Sprintf("%s entity class limit of namespace %s", entityClass, namespace),
+				value: classLimit,
+				count: counts[entityClass],
+			})
+		}
+	}
+	return limits
+}
+
+// endBursts forgets the start of the bursts above the limits that the current
+// counts no longer exceed, so that a later burst is not bounded by the start
+// of a previous one. It must be called with the lock held.
+func (q *Quota) endBursts() {
+	if len(q.burstStarts) == 0 {
+		return
+	}
+	limits := q.limits("", "")
+	for entityClass := range q.entityClassLimits {
+		limits = append(limits, q.limits("", entityClass)...)
+	}
+	for namespace, namespaceLimit := range q.namespaceLimits {
+		limits = append(limits, q.limits(namespace, "")...)
+		for entityClass := range namespaceLimit.EntityClassLimits {
+			limits = append(limits, q.limits(namespace, entityClass)...)
+		}
+	}
+	for _, l := range limits {
+		if l.count < l.value {
+			delete(q.burstStarts, l.name)
+		}
+	}
+}
+
+// overLimits applies the overage policy to every limit the admission of an
+// entity exceeds, and denies it if any of them denies it. Otherwise, the
+// admission beyond the constraining limit is returned, ending with the
+// earliest of the bursts, and the bursts above every exceeded limit start. It
+// must be called with the lock held.
+func (q *Quota) overLimits(admission Admission, limits []quotaLimit, constraining quotaLimit) Admission {
+	result := admission
+	starts := make(map[string]time.Time)
+	// the narrowest denial is reported
+	for i := len(limits) - 1; i >= 0; i-- {
+		l := limits[i]
+		if l.count < l.value {
+			continue
+		}
+		limited := admission
+		limited.Limit = l.value
+		start, bursting := q.overage(&limited, l)
+		if !limited.Allowed {
+			return limited
+		}
+		if bursting {
+			starts[l.name] = start
+			if result.BurstUntil.IsZero() || limited.BurstUntil.Before(result.BurstUntil) {
+				result.BurstUntil = limited.BurstUntil
+			}
+		}
+		if l.name == constraining.name {
+			result.LimitType = limited.LimitType
+			result.Reason = limited.Reason
+		}
+	}
+	for name, start := range starts {
+		q.burstStarts[name] = start
+	}
+	return result
+}
+
+// overage applies the overage policy to the admission of an entity beyond the
+// limit, and returns the start of the burst above the limit when the entity is
+// admitted in a burst. It does not start the burst.
+func (q *Quota) overage(admission *Admission, l quotaLimit) (time.Time, bool) {
+	policy := q.overagePolicy
+	switch {
+	case policy == nil || policy.Mode == OverageBlock:
+		admission.Allowed = false
+		admission.LimitType = LimitHard
+		admission.Reason = fmt.Sprintf("%s reached: %d/%d", l.name, l.count, l.value)
+	case policy.Mode == OverageWarn:
+		admission.LimitType = LimitOverage
+		admission.Reason = fmt.Sprintf("%s exceeded: %d/%d", l.name, l.count+1, l.value)
+	case policy.Mode == OverageBurst:
+		now := q.now()
+		start, ok := q.burstStarts[l.name]
+		if !ok {
+			start = now
+		}
+		until := start.Add(policy.burstDuration())
+		burstLimit := policy.burstLimit(l.value)
+		switch {
+		case l.count >= burstLimit:
+			admission.Allowed = false
+			admission.LimitType = LimitHard
+			admission.Reason = fmt.Sprintf("%s burst reached: %d/%d", l.name, l.count, burstLimit)
+		case !now.Before(until):
+			admission.Allowed = false
+			admission.LimitType = LimitHard
+			admission.Reason = fmt.Sprintf("%s exceeded for more than %s: %d/%d", l.name, policy.BurstDuration, l.count, l.value)
+		default:
+			admission.LimitType = LimitBurst
+			admission.BurstUntil = until
+			admission.Reason = fmt.Sprintf("bursting above the %s: %d/%d", l.name, l.count+1, l.value)
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sum returns the total of the entity counts.
+func sum(counts map[string]int) int {
+	var total int
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
@@ -0,0 +1,247 @@
+package licensing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaAdmit(t *testing.T) {
+	file := &LicenseFile{License: License{
+		EntityLimit:       100,
+		EntityClassLimits: map[string]int{"agent": 50},
+	}}
+
+	tests := []struct {
+		name          string
+		counts        map[string]int
+		entityClass   string
+		wantAllowed   bool
+		wantRemaining int
+		wantLimit     int
+		wantLimitType LimitType
+	}{
+		{
+			name:          "below limits",
+			counts:        map[string]int{"agent": 10, "proxy": 10},
+			entityClass:   "agent",
+			wantAllowed:   true,
+			wantRemaining: 40,
+			wantLimit:     50,
+		},
+		{
+			name:          "class limit reached",
+			counts:        map[string]int{"agent": 50, "proxy": 10},
+			entityClass:   "agent",
+			wantRemaining: 0,
+			wantLimit:     50,
+			wantLimitType: LimitHard,
+		},
+		{
+			name:          "class without limit",
+			counts:        map[string]int{"agent": 50, "proxy": 10},
+			entityClass:   "proxy",
+			wantAllowed:   true,
+			wantRemaining: 40,
+			wantLimit:     100,
+		},
+		{
+			name:          "total limit reached",
+			counts:        map[string]int{"agent": 30, "proxy": 70},
+			entityClass:   "agent",
+			wantRemaining: 0,
+			wantLimit:     100,
+			wantLimitType: LimitHard,
+		},
+		{
+			name:          "above soft limit",
+			counts:        map[string]int{"agent": 45},
+			entityClass:   "agent",
+			wantAllowed:   true,
+			wantRemaining: 5,
+			wantLimit:     50,
+			wantLimitType: LimitSoft,
+		},
+		{
+			name:          "over limit",
+			counts:        map[string]int{"agent": 60},
+			entityClass:   "agent",
+			wantRemaining: 0,
+			wantLimit:     50,
+			wantLimitType: LimitHard,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admission := NewQuota(file, tt.counts).Admit(tt.entityClass)
+			assert.Equal(t, tt.wantAllowed, admission.Allowed)
+			assert.Equal(t, tt.wantRemaining, admission.Remaining)
+			assert.Equal(t, tt.wantLimit, admission.Limit)
+			assert.Equal(t, tt.wantLimitType, admission.LimitType)
+			if tt.wantLimitType != LimitNone {
+				assert.NotEmpty(t, admission.Reason)
+			}
+		})
+	}
+}
+
+func TestQuotaUnlimited(t *testing.T) {
+	quota := NewQuota(&LicenseFile{}, map[string]int{"agent": 1000})
+	admission := quota.Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, Unlimited, admission.Remaining)
+
+	quota = NewQuota(FixtureLicenseFile("default"), nil)
+	quota.SetCount("agent", 20)
+	assert.Equal(t, 20, quota.Count("agent"))
+	assert.False(t, quota.Admit("proxy").Allowed)
+}
+
+func TestQuotaAdmitInNamespace(t *testing.T) {
+	ctx := context.Background()
+	file := &LicenseFile{License: License{
+		EntityLimit:       100,
+		EntityClassLimits: map[string]int{"agent": 50},
+		NamespaceLimits: map[string]NamespaceLimit{
+			"dev":  {EntityLimit: 20, EntityClassLimits: map[string]int{"agent": 5}},
+			"prod": {EntityLimit: 60},
+		},
+	}}
+
+	store := NewMemoryStore()
+	if err := PutEntityUsage(ctx, store, "dev", map[string]int{"agent": 5, "proxy": 10}); err != nil {
+		t.Fatal(err)
+	}
+	quota := NewQuota(file, map[string]int{"agent": 30, "proxy": 30})
+	if err := quota.LoadNamespaceCounts(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 5, quota.NamespaceCount("dev", "agent"))
+
+	admission := quota.AdmitInNamespace("dev", "agent")
+	assert.False(t, admission.Allowed)
+	assert.Equal(t, 5, admission.Limit)
+	assert.Equal(t, LimitHard, admission.LimitType)
+
+	admission = quota.AdmitInNamespace("dev", "proxy")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, 5, admission.Remaining)
+	assert.Equal(t, 20, admission.Limit)
+
+	quota.SetNamespaceCount("prod", "agent", 59)
+	admission = quota.AdmitInNamespace("prod", "agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, 1, admission.Remaining)
+	assert.Equal(t, LimitSoft, admission.LimitType)
+
+	// namespaces without limits share the limits of the license
+	admission = quota.AdmitInNamespace("default", "agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, 20, admission.Remaining)
+	assert.Equal(t, 50, admission.Limit)
+}
+
+func TestQuotaOverage(t *testing.T) {
+	newQuota := func(policy *OveragePolicy) *Quota {
+		file := &LicenseFile{License: License{EntityLimit: 100, OveragePolicy: policy}}
+		return NewQuota(file, map[string]int{"agent": 100})
+	}
+
+	admission := newQuota(nil).Admit("agent")
+	assert.False(t, admission.Allowed)
+	assert.Equal(t, LimitHard, admission.LimitType)
+
+	admission = newQuota(&OveragePolicy{Mode: OverageBlock}).Admit("agent")
+	assert.False(t, admission.Allowed)
+
+	admission = newQuota(&OveragePolicy{Mode: OverageWarn}).Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, 0, admission.Remaining)
+	assert.Equal(t, LimitOverage, admission.LimitType)
+	assert.NotEmpty(t, admission.Reason)
+
+	quota := newQuota(&OveragePolicy{Mode: OverageBurst, BurstPercent: 10, BurstDuration: Duration(time.Hour)})
+	current := now
+	quota.now = func() time.Time { return current }
+
+	admission = quota.Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, LimitBurst, admission.LimitType)
+	assert.Equal(t, now.Add(time.Hour), admission.BurstUntil)
+
+	quota.SetCount("agent", 110)
+	admission = quota.Admit("agent")
+	assert.False(t, admission.Allowed, "the burst should be bounded by the burst percent")
+	assert.Equal(t, LimitHard, admission.LimitType)
+
+	quota.SetCount("agent", 105)
+	current = now.Add(30 * time.Minute)
+	admission = quota.Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, now.Add(time.Hour), admission.BurstUntil, "the burst should not be extended")
+
+	current = now.Add(time.Hour)
+	admission = quota.Admit("agent")
+	assert.False(t, admission.Allowed, "the burst should be bounded by the burst duration")
+	assert.Equal(t, LimitHard, admission.LimitType)
+
+	// the burst is over once the usage is back under the limit
+	quota.SetCount("agent", 50)
+	assert.True(t, quota.Admit("agent").Allowed)
+	quota.SetCount("agent", 100)
+	admission = quota.Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, current.Add(time.Hour), admission.BurstUntil)
+
+	// even when no admission is answered while under the limit
+	quota.SetCount("agent", 50)
+	quota.SetCount("agent", 100)
+	current = current.Add(2 * time.Hour)
+	admission = quota.Admit("agent")
+	assert.True(t, admission.Allowed, "a new burst should not be bounded by the previous one")
+	assert.Equal(t, current.Add(time.Hour), admission.BurstUntil)
+}
+
+func TestQuotaOverageEveryLimit(t *testing.T) {
+	file := &LicenseFile{License: License{
+		EntityLimit:       1000,
+		EntityClassLimits: map[string]int{"agent": 10},
+		OveragePolicy:     &OveragePolicy{Mode: OverageBurst, BurstPercent: 10, BurstDuration: Duration(time.Hour)},
+	}}
+	quota := NewQuota(file, map[string]int{"agent": 11, "proxy": 999})
+	current := now
+	quota.now = func() time.Time { return current }
+
+	// the entity limit is the most exceeded, but the agent burst is over
+	admission := quota.Admit("agent")
+	assert.False(t, admission.Allowed, "the agent class limit burst should be bounded")
+	assert.Equal(t, 10, admission.Limit)
+	assert.Equal(t, LimitHard, admission.LimitType)
+
+	// both limits burst, until the earliest of their bursts
+	quota.SetCount("agent", 10)
+	admission = quota.Admit("agent")
+	assert.True(t, admission.Allowed)
+	assert.Equal(t, LimitBurst, admission.LimitType)
+	assert.Equal(t, now.Add(time.Hour), admission.BurstUntil)
+
+	// the narrowest of the bursts ending together is reported
+	quota.SetCount("proxy", 1010)
+	current = now.Add(time.Hour)
+	admission = quota.Admit("agent")
+	assert.False(t, admission.Allowed, "the agent class limit burst should be bounded by its duration")
+	assert.Equal(t, 10, admission.Limit)
+}
+
+func TestOveragePolicyValidate(t *testing.T) {
+	var policy *OveragePolicy
+	assert.NoError(t, policy.Validate())
+	assert.NoError(t, (&OveragePolicy{Mode: OverageWarn}).Validate())
+	assert.NoError(t, (&OveragePolicy{Mode: OverageBurst, BurstPercent: 20, BurstDuration: Duration(time.Hour)}).Validate())
+	assert.Error(t, (&OveragePolicy{Mode: "ignore"}).Validate())
+	assert.Error(t, (&OveragePolicy{Mode: OverageBlock, BurstPercent: 20}).Validate())
+	assert.Error(t, (&OveragePolicy{Mode: OverageBurst, BurstPercent: 20}).Validate())
+	assert.Error(t, (&OveragePolicy{Mode: OverageBurst, BurstDuration: Duration(time.Hour)}).Validate())
+}
@@ -0,0 +1,310 @@
+package licensing
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// licenseFileSchema is the JSON schema of license files, generated from the
+// LicenseFile type by scripts/make_schema.
+//
+//go:embed license.schema.json
+var licenseFileSchema []byte
+
+// Schema returns the JSON schema of license files, for issuers written in
+// other languages.
+func Schema() []byte {
+	return append([]byte(nil), licenseFileSchema...)
+}
+
+// SchemaError describes a value of a license file which does not conform to
+// the schema.
+type SchemaError struct {
+	// Path is the JSON pointer to the value, e.g. /license/validUntil.
+	Path string
+	// Message describes why the value does not conform to the schema.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// SchemaErrors are the errors of a license file which does not conform to the
+// schema, sorted by path.
+type SchemaErrors []*SchemaError
+
+// Error implements the error interface.
+func (e SchemaErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateSchema validates the JSON encoded license file against the schema
+// returned by Schema. It returns SchemaErrors locating every value which does
+// not conform to the schema, or an error if the license file is not JSON.
+func ValidateSchema(raw []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid license file JSON: %w", err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("invalid license file JSON: unexpected data after the license file")
+	}
+
+	root, err := loadSchema()
+	if err != nil {
+		return err
+	}
+	v := &schemaValidator{root: root}
+	v.validate(root, value, "")
+	if len(v.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		return v.errs[i].Path < v.errs[j].Path
+	})
+	return v.errs
+}
+
+// schema is the subset of JSON schema used by the license file schema.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Defs                 map[string]*schema `json:"$defs"`
+	Type                 schemaTypes        `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []json.RawMessage  `json:"enum"`
+	Format               string             `json:"format"`
+	Pattern              string             `json:"pattern"`
+	Minimum              *json.Number       `json:"minimum"`
+	ContentEncoding      string             `json:"contentEncoding"`
+
+	// reject is set by the false boolean schema, which no value conforms to.
+	reject  bool
+	pattern *regexp.Regexp
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, supporting boolean
+// schemas.
+func (s *schema) UnmarshalJSON(b []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(b, &boolean); err == nil {
+		*s = schema{reject: !boolean}
+		return nil
+	}
+	type plain schema
+	if err := json.Unmarshal(b, (*plain)(s)); err != nil {
+		return err
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = pattern
+	}
+	return nil
+}
+
+// schemaTypes are the types allowed by a schema, given as a string or as a
+// list of strings.
+type schemaTypes []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// loadSchema decodes the embedded license file schema.
+func loadSchema() (*schema, error) {
+	root := &schema{}
+	if err := json.Unmarshal(licenseFileSchema, root); err != nil {
+		return nil, fmt.Errorf("invalid license file schema: %w", err)
+	}
+	return root, nil
+}
+
+// schemaValidator collects the errors of a value validated against a schema.
+type schemaValidator struct {
+	root *schema
+	errs SchemaErrors
+}
+
+// fail records an error at the given path.
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	v.errs = append(v.errs, &SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate validates the value at the given path against the schema.
+func (v *schemaValidator) validate(s *schema, value interface{}, path string) {
+	if s.reject {
+		v.fail(path, "unexpected value")
+		return
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/$defs/")
+		ref, ok := v.root.Defs[name]
+		if !ok {
+			v.fail(path, "unresolved schema reference %s", s.Ref)
+			return
+		}
+		v.validate(ref, value, path)
+	}
+	if len(s.Type) > 0 && !s.hasType(value) {
+		v.fail(path, "expected %s, got %s", strings.Join(s.Type, " or "), jsonType(value))
+		return
+	}
+	if len(s.Enum) > 0 {
+		v.validateEnum(s, value, path)
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, value, path)
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range value {
+				v.validate(s.Items, item, path+"/"+strconv.Itoa(i))
+			}
+		}
+	case string:
+		v.validateString(s, value, path)
+	case json.Number:
+		if s.Minimum != nil {
+			min, _ := new(big.Float).SetString(s.Minimum.String())
+			number, _ := new(big.Float).SetString(value.String())
+			if min != nil && number != nil && number.Cmp(min) < 0 {
+				v.fail(path, "must be at least %s", s.Minimum)
+			}
+		}
+	}
+}
+
+// validateObject validates the properties of an object.
+func (v *schemaValidator) validateObject(s *schema, value map[string]interface{}, path string) {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			v.fail(path+"/"+escapePointer(name), "missing required property")
+		}
+	}
+	for name, property := range value {
+		propertyPath := path + "/" + escapePointer(name)
+		if propertySchema, ok := s.Properties[name]; ok {
+			v.validate(propertySchema, property, propertyPath)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.reject {
+			v.fail(propertyPath, "unknown property")
+			continue
+		}
+		v.validate(s.AdditionalProperties, property, propertyPath)
+	}
+}
+
+// validateString validates the format, pattern and encoding of a string.
+func (v *schemaValidator) validateString(s *schema, value, path string) {
+	if s.Format == "date-time" {
+		if _, err := time.Parse(TimestampFormat, value); err != nil {
+			v.fail(path, "invalid timestamp %q, expected the format %s", value, TimestampFormat)
+		}
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		v.fail(path, "%q does not match the pattern %s", value, s.Pattern)
+	}
+	if s.ContentEncoding == "base64" {
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			v.fail(path, "invalid base64 encoding: %s", err)
+		}
+	}
+}
+
+// validateEnum validates that the value is one of the values of the schema.
+func (v *schemaValidator) validateEnum(s *schema, value interface{}, path string) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		v.fail(path, "%s", err)
+		return
+	}
+	allowed := make([]string, len(s.Enum))
+	for i, enum := range s.Enum {
+		if bytes.Equal(encoded, enum) {
+			return
+		}
+		allowed[i] = string(enum)
+	}
+	v.fail(path, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+// hasType returns whether the value has one of the types of the schema.
+func (s *schema) hasType(value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range s.Type {
+		if t == actual {
+			return true
+		}
+		if t == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON schema type of a value decoded with UseNumber.
+// Integers are numbers that can be decoded into Go integers.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if _, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			return "integer"
+		}
+		if _, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// escapePointer escapes a property name in a JSON pointer.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
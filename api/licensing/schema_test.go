@@ -0,0 +1,188 @@
+package licensing
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchema(t *testing.T) {
+	file := goldenLicenseFile(t)
+	file.License.NamespaceLimits = map[string]NamespaceLimit{
+		"default": {EntityLimit: 10, EntityClassLimits: map[string]int{"agent": 5}},
+	}
+	file.License.OveragePolicy = &OveragePolicy{
+		Mode:          OverageBurst,
+		BurstPercent:  10,
+		BurstDuration: Duration(3 * 24 * time.Hour),
+	}
+	file.License.Binding = &Binding{ClusterID: "cluster", Fingerprints: []string{"a"}}
+	valid, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, ValidateSchema(valid))
+
+	tests := []struct {
+		name   string
+		modify func(license map[string]interface{}, file map[string]interface{})
+		errs   []string
+	}{
+		{
+			name: "missing signature",
+			modify: func(_, file map[string]interface{}) {
+				delete(file, "signature")
+			},
+			errs: []string{"/signature: missing required property"},
+		},
+		{
+			name: "without metadata",
+			modify: func(_, file map[string]interface{}) {
+				delete(file, "metadata")
+			},
+		},
+		{
+			name: "invalid signature encoding",
+			modify: func(_, file map[string]interface{}) {
+				file["signature"] = "not base64!"
+			},
+			errs: []string{"/signature: invalid base64 encoding: illegal base64 data at input byte 3"},
+		},
+		{
+			name: "wrong types",
+			modify: func(license, _ map[string]interface{}) {
+				license["accountID"] = "573"
+				license["entityLimit"] = 1.5
+				license["features"] = []interface{}{"rbac", 42}
+			},
+			errs: []string{
+				"/license/accountID: expected integer, got string",
+				"/license/entityLimit: expected integer, got number",
+				"/license/features/1: expected string, got integer",
+			},
+		},
+		{
+			name: "negative account ID",
+			modify: func(license, _ map[string]interface{}) {
+				license["accountID"] = -1
+			},
+			errs: []string{"/license/accountID: must be at least 0"},
+		},
+		{
+			name: "null features",
+			modify: func(license, _ map[string]interface{}) {
+				license["features"] = nil
+			},
+		},
+		{
+			name: "invalid timestamps",
+			modify: func(license, _ map[string]interface{}) {
+				license["issued"] = "2023-01-01"
+				license["validUntil"] = "2100-01-01T00:00:00-04:00"
+			},
+			errs: []string{`/license/issued: invalid timestamp "2023-01-01", expected the format ` + TimestampFormat},
+		},
+		{
+			name: "unsupported hash algorithm",
+			modify: func(license, _ map[string]interface{}) {
+				license["signature"].(map[string]interface{})["hashAlgorithm"] = "MD5"
+			},
+			errs: []string{`/license/signature/hashAlgorithm: must be one of "SHA256", "sha256"`},
+		},
+		{
+			name: "unknown properties",
+			modify: func(license, file map[string]interface{}) {
+				license["entityLimits"] = 10
+				file["expires"] = true
+			},
+			errs: []string{
+				"/expires: unknown property",
+				"/license/entityLimits: unknown property",
+			},
+		},
+		{
+			name: "nested limits",
+			modify: func(license, _ map[string]interface{}) {
+				limits := license["namespaceLimits"].(map[string]interface{})
+				limits["dev/ops"] = map[string]interface{}{
+					"entityClassLimits": map[string]interface{}{"agent": "ten"},
+				}
+			},
+			errs: []string{"/license/namespaceLimits/dev~1ops/entityClassLimits/agent: expected integer, got string"},
+		},
+		{
+			name: "invalid overage policy",
+			modify: func(license, _ map[string]interface{}) {
+				policy := license["overagePolicy"].(map[string]interface{})
+				policy["mode"] = "allow"
+				policy["burstDuration"] = "3 days"
+			},
+			errs: []string{
+				`/license/overagePolicy/burstDuration: "3 days" does not match the pattern ` + DurationPattern,
+				`/license/overagePolicy/mode: must be one of "block", "warn", "burst"`,
+			},
+		},
+		{
+			name: "null binding",
+			modify: func(license, _ map[string]interface{}) {
+				license["binding"] = nil
+			},
+			errs: []string{"/license/binding: expected object, got null"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := map[string]interface{}{}
+			if err := json.Unmarshal(valid, &file); err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(file["license"].(map[string]interface{}), file)
+			raw, err := json.Marshal(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ValidateSchema(raw)
+			if len(tt.errs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var errs SchemaErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected schema errors, got %v", err)
+			}
+			messages := make([]string, len(errs))
+			for i, err := range errs {
+				messages[i] = err.Error()
+			}
+			assert.Equal(t, tt.errs, messages)
+		})
+	}
+}
+
+func TestValidateSchemaInvalidJSON(t *testing.T) {
+	err := ValidateSchema([]byte(`{"license": `))
+	assert.Error(t, err)
+	var errs SchemaErrors
+	assert.False(t, errors.As(err, &errs))
+
+	err = ValidateSchema([]byte(`[]`))
+	assert.EqualError(t, err, "/: expected object, got array")
+
+	assert.Error(t, ValidateSchema([]byte(`{} {}`)))
+}
+
+func TestSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "#/$defs/LicenseFile", schema["$ref"])
+
+	// the schema is a copy of the embedded one
+	Schema()[0] = 'x'
+	assert.NoError(t, json.Unmarshal(Schema(), &schema))
+}
@@ -0,0 +1,221 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotFound means there is no value stored at the requested key.
+	ErrNotFound = errors.New("key not found")
+	// ErrRevisionMismatch means a compare-and-swap operation failed because
+	// the entry was modified since the expected revision.
+	ErrRevisionMismatch = errors.New("revision mismatch")
+	// ErrInvalidKey means a key cannot be used with a store, e.g. because it
+	// escapes the root directory of a FileStore.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// maxCompareAndSwapAttempts bounds the number of times a read-modify-write of
+// an entry is retried when it keeps being modified concurrently.
+const maxCompareAndSwapAttempts = 10
+
+// Entry is a value held in a Store.
+type Entry struct {
+	// Key is the key of the entry.
+	Key string
+	// Value is the stored value.
+	Value []byte
+	// Revision is the revision at which the entry was last modified.
+	Revision int64
+}
+
+// WatchEventType is the type of change reported by a WatchEvent.
+type WatchEventType int
+
+const (
+	// WatchPut means an entry was created or updated.
+	WatchPut WatchEventType = iota
+	// WatchDelete means an entry was deleted.
+	WatchDelete
+	// WatchError means the watch failed. It is the last event reported
+	// before the channel is closed.
+	WatchError
+)
+
+// WatchEvent reports a change to an entry of a Store.
+type WatchEvent struct {
+	// Type is the type of change.
+	Type WatchEventType
+	// Entry is the changed entry. The value of deleted entries is nil, and
+	// there is no entry for WatchError events.
+	Entry *Entry
+	// Err is the error that ended the watch, for WatchError events.
+	Err error
+}
+
+// Store persists licensing resources under keys built with a KeyBuilder.
+type Store interface {
+	// Get returns the entry stored at key, or ErrNotFound.
+	Get(ctx context.Context, key string) (*Entry, error)
+
+	// Put stores value at key and returns the revision of the entry.
+	Put(ctx context.Context, key string, value []byte) (int64, error)
+
+	// CompareAndSwap stores value at key only if the entry is still at the
+	// given revision, or does not exist when the revision is zero, and
+	// returns the new revision of the entry. ErrRevisionMismatch is returned
+	// otherwise.
+	CompareAndSwap(ctx context.Context, key string, value []byte, revision int64) (int64, error)
+
+	// Delete removes the entry stored at key, or returns ErrNotFound.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the entries stored under prefix, which ends with a
+	// trailing slash, sorted by key.
+	List(ctx context.Context, prefix string) ([]*Entry, error)
+
+	// Watch reports the changes made to the entry stored at key, or to the
+	// entries under key when it ends with a trailing slash. The channel is
+	// closed once ctx is done, or after a WatchError event if the watch
+	// fails, in which case the caller should watch again.
+	Watch(ctx context.Context, key string) <-chan WatchEvent
+}
+
+// GetLicenseFile returns the license file stored at LicenseKey().
+func GetLicenseFile(ctx context.Context, store Store) (*LicenseFile, error) {
+	entry, err := store.Get(ctx, LicenseKey())
+	if err != nil {
+		return nil, err
+	}
+	file := &LicenseFile{}
+	if err := json.Unmarshal(entry.Value, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// PutLicenseFile stores the license file at LicenseKey(). License files with
+// a namespace are refused with ErrNamespaced.
+func PutLicenseFile(ctx context.Context, store Store, file *LicenseFile) error {
+	if err := file.TrySetNamespace(file.ObjectMeta.Namespace); err != nil {
+		return err
+	}
+	value, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	_, err = store.Put(ctx, LicenseKey(), value)
+	return err
+}
+
+// DeleteLicenseFile removes the license file stored at LicenseKey().
+func DeleteLicenseFile(ctx context.Context, store Store) error {
+	return store.Delete(ctx, LicenseKey())
+}
+
+// GetEntityUsage returns the number of entities per entity class of the
+// namespace, stored at EntityUsageKey(namespace).
+func GetEntityUsage(ctx context.Context, store Store, namespace string) (map[string]int, error) {
+	if err := ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	entry, err := store.Get(ctx, EntityUsageKey(namespace))
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	if err := json.Unmarshal(entry.Value, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// PutEntityUsage stores the number of entities per entity class of the
+// namespace at EntityUsageKey(namespace).
+func PutEntityUsage(ctx context.Context, store Store, namespace string, counts map[string]int) error {
+	if err := ValidateNamespace(namespace); err != nil {
+		return err
+	}
+	value, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+	_, err = store.Put(ctx, EntityUsageKey(namespace), value)
+	return err
+}
+
+// watchesKey returns whether a watch on watched reports changes to key.
+func watchesKey(watched, key string) bool {
+	if strings.HasSuffix(watched, keySeparator) {
+		return strings.HasPrefix(key, watched)
+	}
+	return watched == key
+}
+
+// eventQueue delivers events in order, without blocking the producer of the
+// events on the consumer.
+type eventQueue[T any] struct {
+	mu     sync.Mutex
+	events []T
+	closed bool
+	ready  chan struct{}
+}
+
+// newEventQueue creates an eventQueue delivering its events to the returned
+// channel until ctx is done.
+func newEventQueue[T any](ctx context.Context) (*eventQueue[T], <-chan T) {
+	q := &eventQueue[T]{ready: make(chan struct{}, 1)}
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.ready:
+			}
+			q.mu.Lock()
+			events, closed := q.events, q.closed
+			q.events = nil
+			q.mu.Unlock()
+			for _, event := range events {
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if closed {
+				return
+			}
+		}
+	}()
+	return q, ch
+}
+
+// close closes the channel of the queue once the events queued so far are
+// delivered. No event may be pushed afterwards.
+func (q *eventQueue[T]) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// push queues an event for delivery.
+func (q *eventQueue[T]) push(event T) {
+	q.mu.Lock()
+	q.events = append(q.events, event)
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,225 @@
+package licensing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStores(t *testing.T) map[string]Store {
+	fileStore := NewFileStore(t.TempDir())
+	fileStore.PollInterval = 10 * time.Millisecond
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+		"etcd":   newTestEtcdStore(t),
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan WatchEvent) WatchEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+	return WatchEvent{}
+}
+
+func TestStore(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := LicenseKey()
+
+			_, err := store.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, store.Delete(ctx, key), ErrNotFound)
+
+			rev, err := store.Put(ctx, key, []byte("foo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, &Entry{Key: key, Value: []byte("foo"), Revision: rev}, entry)
+
+			newRev, err := store.Put(ctx, key, []byte("bar"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Greater(t, newRev, rev)
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatal(err)
+			}
+			_, err = store.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestStoreCompareAndSwap(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := LicenseKey()
+
+			_, err := store.CompareAndSwap(ctx, key, []byte("foo"), 42)
+			assert.ErrorIs(t, err, ErrRevisionMismatch)
+
+			rev, err := store.CompareAndSwap(ctx, key, []byte("foo"), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = store.CompareAndSwap(ctx, key, []byte("bar"), 0)
+			assert.ErrorIs(t, err, ErrRevisionMismatch)
+
+			newRev, err := store.CompareAndSwap(ctx, key, []byte("bar"), rev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = store.CompareAndSwap(ctx, key, []byte("baz"), rev)
+			assert.ErrorIs(t, err, ErrRevisionMismatch)
+
+			entry, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, []byte("bar"), entry.Value)
+			assert.Equal(t, newRev, entry.Revision)
+		})
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			prefix := IssuedLicensesKeyBuilder.BuildPrefix()
+
+			entries, err := store.List(ctx, prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Empty(t, entries)
+
+			for _, key := range []string{IssuedLicenseKey("b"), IssuedLicenseKey("a"), LicenseKey()} {
+				if _, err := store.Put(ctx, key, []byte(key)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			entries, err = store.List(ctx, prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if assert.Len(t, entries, 2) {
+				assert.Equal(t, IssuedLicenseKey("a"), entries[0].Key)
+				assert.Equal(t, []byte(IssuedLicenseKey("a")), entries[0].Value)
+				assert.Equal(t, IssuedLicenseKey("b"), entries[1].Key)
+			}
+		})
+	}
+}
+
+func TestStoreWatch(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			builder := NewKeyBuilder("test")
+
+			keyEvents := store.Watch(ctx, builder.Build("a"))
+			prefixEvents := store.Watch(ctx, builder.BuildPrefix())
+
+			if _, err := store.Put(ctx, builder.Build("a"), []byte("foo")); err != nil {
+				t.Fatal(err)
+			}
+			event := nextEvent(t, keyEvents)
+			assert.Equal(t, WatchPut, event.Type)
+			assert.Equal(t, []byte("foo"), event.Entry.Value)
+			event = nextEvent(t, prefixEvents)
+			assert.Equal(t, builder.Build("a"), event.Entry.Key)
+
+			if _, err := store.Put(ctx, builder.Build("b"), []byte("bar")); err != nil {
+				t.Fatal(err)
+			}
+			event = nextEvent(t, prefixEvents)
+			assert.Equal(t, WatchPut, event.Type)
+			assert.Equal(t, builder.Build("b"), event.Entry.Key)
+
+			if err := store.Delete(ctx, builder.Build("a")); err != nil {
+				t.Fatal(err)
+			}
+			event = nextEvent(t, keyEvents)
+			assert.Equal(t, WatchDelete, event.Type)
+			assert.Equal(t, builder.Build("a"), event.Entry.Key)
+
+			cancel()
+			for range keyEvents {
+			}
+		})
+	}
+}
+
+func TestLicenseFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	file := testMockLicenseFile()
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PutLicenseFile(ctx, store, file); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.License, stored.License)
+	assert.Equal(t, file.Signature, stored.Signature)
+
+	if err := DeleteLicenseFile(ctx, store); err != nil {
+		t.Fatal(err)
+	}
+	_, err = GetLicenseFile(ctx, store)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	key := LicenseKey()
+
+	rev, err := NewFileStore(root).Put(ctx, key, []byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// revisions keep increasing across instances of the store
+	store := NewFileStore(root)
+	newRev, err := store.Put(ctx, NewKeyBuilder("test").Build("a"), []byte("bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Greater(t, newRev, rev)
+	entry, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &Entry{Key: key, Value: []byte("foo"), Revision: rev}, entry)
+
+	for _, key := range []string{"../outside", "/a/../../outside"} {
+		_, err = store.Put(ctx, key, []byte("foo"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+		_, err = store.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+		assert.ErrorIs(t, store.Delete(ctx, key), ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,247 @@
+package licensing
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// TrialPlan is the plan of trial licenses.
+	TrialPlan = "trial"
+	// DefaultTrialDuration is the default validity period of trial licenses.
+	DefaultTrialDuration = 14 * 24 * time.Hour
+	// MaxTrialDuration is the maximum validity period of trial licenses.
+	MaxTrialDuration = 30 * 24 * time.Hour
+	// MaxTrialEntityLimit is the maximum entity limit of trial licenses.
+	MaxTrialEntityLimit = 100
+)
+
+// TrialFeatures are the features trial licenses may enable.
+var TrialFeatures = FeatureList{"rbac", "ldap"}
+
+var (
+	// ErrTrialUsed is returned when a trial license is applied to an
+	// installation which already used another trial.
+	ErrTrialUsed = errors.New("installation already used a trial license")
+	// ErrTrialNotAccepted is returned when a trial license is validated by a
+	// validator without a trial public key.
+	ErrTrialNotAccepted = errors.New("trial licenses are not accepted")
+)
+
+// TrialSpec describes a trial license to generate.
+type TrialSpec struct {
+	// AccountName is the name of the account trying the product.
+	AccountName string
+	// InstallationID is the fingerprint of the installation the trial is
+	// bound to.
+	InstallationID string
+	// Duration is the validity period of the trial, or DefaultTrialDuration
+	// when zero.
+	Duration time.Duration
+	// EntityLimit is the entity limit of the trial, or MaxTrialEntityLimit
+	// when zero.
+	EntityLimit int
+	// Features are the features enabled by the trial, or TrialFeatures when
+	// empty.
+	Features FeatureList
+}
+
+// GenerateTrialLicense generates a trial license file issued at the given time,
+// bound to the installation, and signs it locally with the given signer, which
+// must hold the RSA key of the installation.
+func GenerateTrialLicense(spec TrialSpec, signer crypto.Signer, issued time.Time) (*LicenseFile, error) {
+	if spec.InstallationID == "" {
+		return nil, errors.New("trial licenses must be bound to an installation")
+	}
+	duration := spec.Duration
+	if duration == 0 {
+		duration = DefaultTrialDuration
+	}
+	entityLimit := spec.EntityLimit
+	if entityLimit == 0 {
+		entityLimit = MaxTrialEntityLimit
+	}
+	features := spec.Features
+	if len(features) == 0 {
+		features = TrialFeatures
+	}
+
+	// timestamps are serialized with a precision of one second
+	issued = issued.Truncate(time.Second)
+	file := &LicenseFile{
+		License: License{
+			Version:          SupportedLicenseVersion,
+			Issuer:           spec.InstallationID,
+			AccountName:      spec.AccountName,
+			Issued:           Timestamp(issued),
+			ValidUntil:       Timestamp(issued.Add(duration)),
+			Plan:             TrialPlan,
+			Features:         features,
+			SignatureOptions: DefaultSignatureOptions,
+			EntityLimit:      entityLimit,
+			Binding:          &Binding{Fingerprints: []string{spec.InstallationID}},
+			Trial:            true,
+		},
+	}
+	if err := file.ValidateTrial(); err != nil {
+		return nil, err
+	}
+	if err := SignLicenseFileWithSigner(file, signer); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// ValidateTrial validates that a trial license file stays within the limits
+// of trials. Licenses that are not trials are valid.
+func (f *LicenseFile) ValidateTrial() error {
+	license := &f.License
+	if !license.Trial {
+		return nil
+	}
+	if license.Binding == nil || len(license.Binding.Fingerprints) != 1 {
+		return errors.New("trial licenses must be bound to a single installation")
+	}
+	duration := time.Time(license.ValidUntil).Sub(time.Time(license.Issued))
+	if duration <= 0 || duration > MaxTrialDuration {
+		return fmt.Errorf("trial duration exceeds the maximum of %s: %s", Duration(MaxTrialDuration), Duration(duration))
+	}
+	if license.EntityLimit <= 0 || license.EntityLimit > MaxTrialEntityLimit {
+		return fmt.Errorf("trial entity limit must be between 1 and %d: %d", MaxTrialEntityLimit, license.EntityLimit)
+	}
+	for _, feature := range license.Features {
+		if !containsFeature(TrialFeatures, feature) {
+			return fmt.Errorf("feature %q is not available on trials", feature)
+		}
+	}
+	if len(license.NamespaceLimits) > 0 || license.OveragePolicy != nil || license.AllowTessenOptOut {
+		return errors.New("trial licenses cannot override the policies of the trial plan")
+	}
+	return nil
+}
+
+// containsFeature returns whether the feature is in the list.
+func containsFeature(features FeatureList, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// TrialRecord records the trial used by an installation.
+type TrialRecord struct {
+	// InstallationID is the fingerprint of the installation.
+	InstallationID string `json:"installationID"`
+	// LicenseID is the ID of the trial license.
+	LicenseID string `json:"licenseID"`
+	// Accepted is the time at which the trial was first accepted.
+	Accepted Timestamp `json:"accepted"`
+}
+
+// TrialLedger records the trials used by installations in a store, so that
+// each installation can only use a single trial.
+type TrialLedger struct {
+	store Store
+
+	// now returns the time at which trials are accepted.
+	now func() time.Time
+}
+
+// NewTrialLedger creates a TrialLedger recording trials in the given store.
+func NewTrialLedger(store Store) *TrialLedger {
+	return &TrialLedger{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Accept records the trial license file as the trial of its installation, or
+// returns ErrTrialUsed if the installation already used another trial.
+// Accepting the same trial again succeeds.
+func (l *TrialLedger) Accept(ctx context.Context, file *LicenseFile) error {
+	installationID, err := trialInstallation(file)
+	if err != nil {
+		return err
+	}
+	key := TrialKey(installationID)
+
+	for attempt := 0; attempt < maxCompareAndSwapAttempts; attempt++ {
+		record, err := l.Get(ctx, installationID)
+		if err == nil {
+			if record.LicenseID != file.ID() {
+				return fmt.Errorf("%w: %s", ErrTrialUsed, installationID)
+			}
+			return nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		value, err := json.Marshal(&TrialRecord{
+			InstallationID: installationID,
+			LicenseID:      file.ID(),
+			Accepted:       Timestamp(l.now().Truncate(time.Second)),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = l.store.CompareAndSwap(ctx, key, value, 0)
+		if errors.Is(err, ErrRevisionMismatch) {
+			// another trial was accepted concurrently
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("cannot record the trial: %w", ErrRevisionMismatch)
+}
+
+// Check returns ErrTrialUsed if the installation of the trial license file
+// already used another trial, without recording the trial.
+func (l *TrialLedger) Check(ctx context.Context, file *LicenseFile) error {
+	installationID, err := trialInstallation(file)
+	if err != nil {
+		return err
+	}
+	record, err := l.Get(ctx, installationID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if record.LicenseID != file.ID() {
+		return fmt.Errorf("%w: %s", ErrTrialUsed, installationID)
+	}
+	return nil
+}
+
+// trialInstallation returns the installation the trial license file is bound
+// to.
+func trialInstallation(file *LicenseFile) (string, error) {
+	if err := file.ValidateTrial(); err != nil {
+		return "", err
+	}
+	if !file.License.Trial {
+		return "", errors.New("license is not a trial")
+	}
+	return file.License.Binding.Fingerprints[0], nil
+}
+
+// Get returns the trial record of the installation, or ErrNotFound.
+func (l *TrialLedger) Get(ctx context.Context, installationID string) (*TrialRecord, error) {
+	entry, err := l.store.Get(ctx, TrialKey(installationID))
+	if err != nil {
+		return nil, err
+	}
+	record := &TrialRecord{}
+	if err := json.Unmarshal(entry.Value, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
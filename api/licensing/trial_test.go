@@ -0,0 +1,198 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTrialLicense(t *testing.T) {
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := GenerateTrialLicense(TrialSpec{AccountName: "Acme Corp.", InstallationID: "install-1"}, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, file.License.Trial)
+	assert.Equal(t, TrialPlan, file.License.Plan)
+	assert.Equal(t, MaxTrialEntityLimit, file.License.EntityLimit)
+	assert.Equal(t, TrialFeatures, file.License.Features)
+	assert.Equal(t, Timestamp(now.Add(DefaultTrialDuration)), file.License.ValidUntil)
+
+	identity := &InstallationIdentity{Fingerprint: "install-1"}
+	validator := &Validator{PublicKey: testPublicKey, Identity: identity}
+	assert.ErrorIs(t, validator.Validate(file), ErrTrialNotAccepted)
+	validator = &Validator{PublicKey: testPublicKey, TrialPublicKey: testPublicKey, Identity: identity}
+	assert.NoError(t, validator.Validate(file))
+
+	validator.Identity = &InstallationIdentity{Fingerprint: "install-2"}
+	assert.ErrorIs(t, validator.Validate(file), ErrWrongInstallation)
+
+	_, err = GenerateTrialLicense(TrialSpec{InstallationID: "install-1", EntityLimit: 1000}, key, now)
+	assert.Error(t, err)
+	_, err = GenerateTrialLicense(TrialSpec{InstallationID: "install-1", Duration: 365 * 24 * time.Hour}, key, now)
+	assert.Error(t, err)
+	_, err = GenerateTrialLicense(TrialSpec{InstallationID: "install-1", Features: FeatureList{AllFeatures}}, key, now)
+	assert.Error(t, err)
+	_, err = GenerateTrialLicense(TrialSpec{}, key, now)
+	assert.Error(t, err)
+
+	// trial limits are enforced on validation, not only on generation
+	file.License.EntityLimit = 1000
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	validator.Identity = identity
+	assert.Error(t, validator.Validate(file))
+}
+
+func TestTrialLedger(t *testing.T) {
+	ctx := context.Background()
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	handler := NewLicenseHandler(store, &Validator{
+		PublicKey:      testPublicKey,
+		TrialPublicKey: testPublicKey,
+		Identity:       &InstallationIdentity{Fingerprint: "install-1"},
+	})
+
+	first, err := GenerateTrialLicense(TrialSpec{AccountName: "Acme Corp.", InstallationID: "install-1"}, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusOK, rec.Code, "the same trial can be applied again")
+
+	record, err := NewTrialLedger(store).Get(ctx, "install-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, first.ID(), record.LicenseID)
+
+	second, err := GenerateTrialLicense(TrialSpec{AccountName: "Acme Corp.", InstallationID: "install-1"}, key, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err = json.Marshal(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = doRequest(handler, http.MethodPut, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	report := &ValidationReport{}
+	if err := json.Unmarshal(rec.Body.Bytes(), report); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, report.Valid)
+	assert.Contains(t, report.Error, ErrTrialUsed.Error())
+	assert.ErrorIs(t, NewTrialLedger(store).Accept(ctx, second), ErrTrialUsed)
+
+	active, err := GetLicenseFile(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, first.Signature, active.Signature)
+
+	// the trial is also refused when written directly to the store
+	if err := PutLicenseFile(ctx, store, second); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewManager(store, &Validator{
+		PublicKey:      testPublicKey,
+		TrialPublicKey: testPublicKey,
+		Identity:       &InstallationIdentity{Fingerprint: "install-1"},
+	})
+	status := manager.evaluate(ctx, second)
+	assert.Equal(t, StateInvalid, status.State)
+	assert.ErrorIs(t, status.Err, ErrTrialUsed)
+	assert.NotEqual(t, StateInvalid, manager.evaluate(ctx, first).State)
+}
+
+// unavailableKeyStore is a Store failing every read and write of a key.
+type unavailableKeyStore struct {
+	*MemoryStore
+
+	key string
+}
+
+var errUnavailable = errors.New("store unavailable")
+
+func (s *unavailableKeyStore) Get(ctx context.Context, key string) (*Entry, error) {
+	if key == s.key {
+		return nil, errUnavailable
+	}
+	return s.MemoryStore.Get(ctx, key)
+}
+
+func (s *unavailableKeyStore) CompareAndSwap(ctx context.Context, key string, value []byte, revision int64) (int64, error) {
+	if key == s.key {
+		return 0, errUnavailable
+	}
+	return s.MemoryStore.CompareAndSwap(ctx, key, value, revision)
+}
+
+func TestTrialLedgerReadOnly(t *testing.T) {
+	ctx := context.Background()
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := &Validator{
+		PublicKey:      testPublicKey,
+		TrialPublicKey: testPublicKey,
+		Identity:       &InstallationIdentity{Fingerprint: "install-1"},
+	}
+	trial, err := GenerateTrialLicense(TrialSpec{AccountName: "Acme Corp.", InstallationID: "install-1"}, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// evaluating a trial does not record it
+	store := NewMemoryStore()
+	assert.NotEqual(t, StateInvalid, NewManager(store, validator).evaluate(ctx, trial).State)
+	_, err = NewTrialLedger(store).Get(ctx, "install-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// nor does a ledger that cannot be read invalidate it
+	failing := &unavailableKeyStore{MemoryStore: NewMemoryStore(), key: TrialKey("install-1")}
+	assert.NotEqual(t, StateInvalid, NewManager(failing, validator).evaluate(ctx, trial).State)
+
+	// the trial is not used up when it cannot be recorded in the history
+	failing = &unavailableKeyStore{MemoryStore: store, key: LicenseHistoryKey()}
+	_, err = NewHistory(failing, 10, validator).Apply(ctx, trial, "alice")
+	assert.ErrorIs(t, err, errUnavailable)
+	_, err = NewTrialLedger(store).Get(ctx, "install-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	if _, err := NewHistory(store, 10, validator).Apply(ctx, trial, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	record, err := NewTrialLedger(store).Get(ctx, "install-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, trial.ID(), record.LicenseID)
+}
+
+func TestTrialKey(t *testing.T) {
+	assert.NotEqual(t, LicenseKey(), TrialKey("../license"))
+	assert.True(t, strings.HasPrefix(TrialKey("../license"), TrialKeyBuilder.BuildPrefix()))
+	assert.NotEqual(t, TrialKey("install-1"), TrialKey("install-2"))
+}
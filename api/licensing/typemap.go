@@ -6,25 +6,77 @@ import (
 	"path"
 
 	corev2 "github.com/sensu/core/v2"
+	corev3 "github.com/sensu/core/v3"
 	apitools "github.com/sensu/sensu-api-tools"
 )
 
 func init() {
 	for alias, v := range typeMap {
-		if _, ok := v.(corev2.Resource); ok {
-			apitools.RegisterType(
-				path.Join(GroupName, Version),
-				v,
-				apitools.WithAlias(alias),
-			)
+		opts := []apitools.ResolveOption{apitools.WithAlias(alias)}
+		if _, ok := v.(corev3.Resource); ok {
+			opts = append(opts, apitools.WithResolveHook(resolveResource))
+		} else if _, ok := v.(corev2.Resource); !ok {
+			continue
 		}
+		apitools.RegisterType(path.Join(GroupName, Version), v, opts...)
 	}
 }
 
+// resolveResource initializes the metadata of the resolved core/v3 resources,
+// as the core/v3 resolver does.
+func resolveResource(v interface{}) {
+	resource, ok := v.(corev3.Resource)
+	if !ok {
+		return
+	}
+	resource.SetMetadata(&corev2.ObjectMeta{
+		Labels:      make(map[string]string),
+		Annotations: make(map[string]string),
+	})
+}
+
 // typeMap is used to dynamically look up data types from strings.
 var typeMap = map[string]interface{}{
-	"key_builder":       &KeyBuilder{},
-	"license":           &License{},
-	"license_file":      &LicenseFile{},
-	"signature_options": &SignatureOptions{},
+	"activation_request":        &ActivationRequest{},
+	"activation_token":          &ActivationToken{},
+	"admission":                 &Admission{},
+	"binding":                   &Binding{},
+	"entitlements":              &Entitlements{},
+	"entry":                     &Entry{},
+	"etcd_store":                &EtcdStore{},
+	"event":                     &Event{},
+	"file_store":                &FileStore{},
+	"history":                   &History{},
+	"history_record":            &HistoryRecord{},
+	"installation_identity":     &InstallationIdentity{},
+	"issuer":                    &Issuer{},
+	"key_builder":               &KeyBuilder{},
+	"license":                   &License{},
+	"license_file":              &LicenseFile{},
+	"license_handler":           &LicenseHandler{},
+	"license_spec":              &LicenseSpec{},
+	"license_status":            &LicenseStatus{},
+	"manager":                   &Manager{},
+	"memory_store":              &MemoryStore{},
+	"namespace_limit":           &NamespaceLimit{},
+	"overage_policy":            &OveragePolicy{},
+	"plan":                      &Plan{},
+	"quota":                     &Quota{},
+	"schema_error":              &SchemaError{},
+	"signature_options":         &SignatureOptions{},
+	"signed_activation_request": &SignedActivationRequest{},
+	"signed_activation_token":   &SignedActivationToken{},
+	"signed_usage_report":       &SignedUsageReport{},
+	"trial_ledger":              &TrialLedger{},
+	"trial_record":              &TrialRecord{},
+	"trial_spec":                &TrialSpec{},
+	"usage_peak":                &UsagePeak{},
+	"usage_recorder":            &UsageRecorder{},
+	"usage_report":              &UsageReport{},
+	"usage_rollup":              &UsageRollup{},
+	"usage_sample":              &UsageSample{},
+	"usage_stats":               &UsageStats{},
+	"validation_report":         &ValidationReport{},
+	"validator":                 &Validator{},
+	"watch_event":               &WatchEvent{},
 }
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	corev2 "github.com/sensu/core/v2"
+	corev3 "github.com/sensu/core/v3"
 	apitools "github.com/sensu/sensu-api-tools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLicenseFile(t *testing.T) {
@@ -17,3 +19,31 @@ func TestLicenseFile(t *testing.T) {
 		t.Error("expected LicenseFile")
 	}
 }
+
+func TestLicenseFileV3Resource(t *testing.T) {
+	v, err := apitools.Resolve("licensing/v2", "LicenseFile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, ok := v.(corev3.Resource)
+	if !ok {
+		t.Fatal("expected a core/v3 resource")
+	}
+	meta := resource.GetMetadata()
+	assert.Equal(t, LicenseResource, meta.Name)
+	assert.NotNil(t, meta.Labels, "resolved resources should have initialized metadata")
+	assert.NotNil(t, meta.Annotations)
+	assert.Equal(t, "license_file", resource.StoreName())
+	assert.Equal(t, LicenseResource, resource.RBACName())
+	assert.Equal(t, LicenseURI(), resource.URIPath())
+
+	global, ok := v.(corev3.GlobalResource)
+	if assert.True(t, ok) {
+		assert.True(t, global.IsGlobalResource())
+	}
+
+	// license files flow through the v2 compatibility shim of core/v3
+	proxy := corev3.V3ToV2Resource(resource)
+	assert.Equal(t, resource.StoreName(), proxy.StorePrefix())
+	assert.Equal(t, LicenseResource, proxy.GetObjectMeta().Name)
+}
@@ -2,3 +2,4 @@ package licensing
 
 //go:generate go run ../../scripts/make_typemap/make_typemap.go -t typemap.tmpl -o typemap.go
 //go:generate go fmt typemap.go
+//go:generate go run ../../scripts/make_schema/make_schema.go -o license.schema.json
@@ -0,0 +1,297 @@
+package licensing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHourlyRollups is the default number of hourly usage rollups kept
+	// by a UsageRecorder.
+	DefaultHourlyRollups = 72
+	// DefaultDailyRollups is the default number of daily usage rollups kept by
+	// a UsageRecorder.
+	DefaultDailyRollups = 400
+)
+
+// RollupGranularity is the period covered by a usage rollup.
+type RollupGranularity string
+
+const (
+	// RollupHourly rollups cover an hour.
+	RollupHourly RollupGranularity = "hourly"
+	// RollupDaily rollups cover a day, in UTC.
+	RollupDaily RollupGranularity = "daily"
+)
+
+// period returns the duration covered by rollups of the granularity.
+func (g RollupGranularity) period() time.Duration {
+	if g == RollupDaily {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// UsagePeak holds the peak number of entities of a namespace over a period.
+type UsagePeak struct {
+	// Peak is the highest number of entities per entity class.
+	Peak map[string]int `json:"peak"`
+	// PeakTotal is the highest total number of entities.
+	PeakTotal int `json:"peakTotal"`
+}
+
+// UsageRollup holds the peak number of entities over a period.
+type UsageRollup struct {
+	// Start is the beginning of the period.
+	Start Timestamp `json:"start"`
+	// Peak is the highest number of entities per entity class.
+	Peak map[string]int `json:"peak"`
+	// PeakTotal is the highest total number of entities.
+	PeakTotal int `json:"peakTotal"`
+	// Namespaces are the peaks per namespace.
+	Namespaces map[string]UsagePeak `json:"namespaces,omitempty"`
+}
+
+// UsageRecorder records periodic samples of the number of entities, and keeps
+// hourly and daily rollups of their peaks at UsageRollupKey(). The number of
+// rollups kept is bounded, so that memory usage does not grow over time.
+type UsageRecorder struct {
+	// MaxHourlyRollups is the number of hourly rollups kept.
+	MaxHourlyRollups int
+	// MaxDailyRollups is the number of daily rollups kept.
+	MaxDailyRollups int
+
+	store Store
+
+	mu      sync.Mutex
+	rollups map[RollupGranularity][]UsageRollup
+}
+
+// NewUsageRecorder creates a UsageRecorder persisting its rollups in the given
+// store.
+func NewUsageRecorder(store Store) *UsageRecorder {
+	return &UsageRecorder{
+		MaxHourlyRollups: DefaultHourlyRollups,
+		MaxDailyRollups:  DefaultDailyRollups,
+		store:            store,
+		rollups:          make(map[RollupGranularity][]UsageRollup),
+	}
+}
+
+// Load reads the rollups persisted in the store, replacing those in memory.
+func (r *UsageRecorder) Load(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, granularity := range []RollupGranularity{RollupHourly, RollupDaily} {
+		entry, err := r.store.Get(ctx, UsageRollupKey(granularity))
+		if errors.Is(err, ErrNotFound) {
+			r.rollups[granularity] = nil
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var rollups []UsageRollup
+		if err := json.Unmarshal(entry.Value, &rollups); err != nil {
+			return err
+		}
+		r.rollups[granularity] = r.trim(granularity, rollups)
+	}
+	return nil
+}
+
+// Record accounts for a sample of the number of entities in the rollups of
+// its period, and persists the rollups that changed.
+func (r *UsageRecorder) Record(ctx context.Context, sample UsageSample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, granularity := range []RollupGranularity{RollupHourly, RollupDaily} {
+		rollups, changed := addToRollups(r.rollups[granularity], granularity, time.Time(sample.Time), sample.Counts)
+		if !changed {
+			continue
+		}
+		rollups = r.trim(granularity, rollups)
+		value, err := json.Marshal(rollups)
+		if err != nil {
+			return err
+		}
+		if _, err := r.store.Put(ctx, UsageRollupKey(granularity), value); err != nil {
+			return err
+		}
+		r.rollups[granularity] = rollups
+	}
+	return nil
+}
+
+// Rollups returns the rollups of the given granularity, oldest first.
+func (r *UsageRecorder) Rollups(granularity RollupGranularity) []UsageRollup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]UsageRollup(nil), r.rollups[granularity]...)
+}
+
+// Peak returns the highest number of entities of the class since the given
+// time. Hourly rollups are used when they cover that time, otherwise daily
+// rollups are, in which case the whole day of since is accounted for.
+func (r *UsageRecorder) Peak(entityClass string, since time.Time) int {
+	var peak int
+	for _, rollup := range r.since(since) {
+		if rollup.Peak[entityClass] > peak {
+			peak = rollup.Peak[entityClass]
+		}
+	}
+	return peak
+}
+
+// PeakTotal returns the highest total number of entities since the given
+// time, with the same precision as Peak.
+func (r *UsageRecorder) PeakTotal(since time.Time) int {
+	var peak int
+	for _, rollup := range r.since(since) {
+		if rollup.PeakTotal > peak {
+			peak = rollup.PeakTotal
+		}
+	}
+	return peak
+}
+
+// since returns the rollups of the finest granularity covering the periods
+// after the given time.
+func (r *UsageRecorder) since(since time.Time) []UsageRollup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	granularity := RollupDaily
+	if hourly := r.rollups[RollupHourly]; len(hourly) > 0 && !time.Time(hourly[0].Start).After(since) {
+		granularity = RollupHourly
+	}
+	var rollups []UsageRollup
+	for _, rollup := range r.rollups[granularity] {
+		if time.Time(rollup.Start).Add(granularity.period()).After(since) {
+			rollups = append(rollups, rollup)
+		}
+	}
+	return rollups
+}
+
+// trim drops the oldest rollups beyond the number kept for the granularity.
+func (r *UsageRecorder) trim(granularity RollupGranularity, rollups []UsageRollup) []UsageRollup {
+	max := r.MaxHourlyRollups
+	if granularity == RollupDaily {
+		max = r.MaxDailyRollups
+	}
+	if max > 0 && len(rollups) > max {
+		rollups = append([]UsageRollup(nil), rollups[len(rollups)-max:]...)
+	}
+	return rollups
+}
+
+// Report builds the usage report of the license by the given installation
+// over the period [start, end) from the rollups, with the same precision as
+// Peak. Each rollup counts as a sample of its peaks, so the averages of the
+// report are the averages of the hourly or daily peaks.
+func (r *UsageRecorder) Report(file *LicenseFile, installation InstallationIdentity, start, end time.Time) (*UsageReport, error) {
+	var rollups []UsageRollup
+	for _, rollup := range r.since(start) {
+		if time.Time(rollup.Start).Before(end) {
+			rollups = append(rollups, rollup)
+		}
+	}
+
+	usage := newUsageAccumulator()
+	namespaces := map[string]*usageAccumulator{}
+	for _, rollup := range rollups {
+		usage.addPeak(rollup.Peak, rollup.PeakTotal)
+		for namespace, peak := range rollup.Namespaces {
+			accumulator, ok := namespaces[namespace]
+			if !ok {
+				accumulator = newUsageAccumulator()
+				namespaces[namespace] = accumulator
+			}
+			accumulator.addPeak(peak.Peak, peak.PeakTotal)
+		}
+	}
+	return newUsageReport(file, installation, start, end, len(rollups), usage, namespaces)
+}
+
+// addToRollups accounts for the counts per entity class per namespace sampled
+// at t in the rollup of its period, and returns the resulting rollups, sorted
+// by period, and whether they changed. The given rollups are not modified.
+func addToRollups(rollups []UsageRollup, granularity RollupGranularity, t time.Time, counts map[string]map[string]int) ([]UsageRollup, bool) {
+	start := t.UTC().Truncate(granularity.period())
+
+	// samples usually belong to the latest period, so search from the end
+	i := len(rollups)
+	for i > 0 && time.Time(rollups[i-1].Start).After(start) {
+		i--
+	}
+	if i > 0 && time.Time(rollups[i-1].Start).Equal(start) {
+		rollup, changed := rollups[i-1].add(counts)
+		if !changed {
+			return rollups, false
+		}
+		updated := append([]UsageRollup(nil), rollups...)
+		updated[i-1] = rollup
+		return updated, true
+	}
+
+	rollup, _ := UsageRollup{Start: Timestamp(start)}.add(counts)
+	updated := make([]UsageRollup, 0, len(rollups)+1)
+	updated = append(updated, rollups[:i]...)
+	updated = append(updated, rollup)
+	updated = append(updated, rollups[i:]...)
+	return updated, true
+}
+
+// add returns a copy of the rollup accounting for the counts per entity class
+// per namespace, and whether its peaks changed.
+func (r UsageRollup) add(counts map[string]map[string]int) (UsageRollup, bool) {
+	total := map[string]int{}
+	namespaces := make(map[string]UsagePeak, len(r.Namespaces)+len(counts))
+	for namespace, peak := range r.Namespaces {
+		namespaces[namespace] = peak
+	}
+	var changed bool
+	for namespace, namespaceCounts := range counts {
+		peak, namespaceChanged := namespaces[namespace].add(namespaceCounts)
+		namespaces[namespace] = peak
+		changed = changed || namespaceChanged
+		for entityClass, count := range namespaceCounts {
+			total[entityClass] += count
+		}
+	}
+	peak, totalChanged := UsagePeak{Peak: r.Peak, PeakTotal: r.PeakTotal}.add(total)
+
+	r.Peak, r.PeakTotal = peak.Peak, peak.PeakTotal
+	r.Namespaces = nil
+	if len(namespaces) > 0 {
+		r.Namespaces = namespaces
+	}
+	return r, changed || totalChanged
+}
+
+// add returns a copy of the peak accounting for the counts per entity class,
+// and whether it changed.
+func (p UsagePeak) add(counts map[string]int) (UsagePeak, bool) {
+	peak := make(map[string]int, len(p.Peak)+len(counts))
+	for entityClass, count := range p.Peak {
+		peak[entityClass] = count
+	}
+	var total int
+	changed := p.Peak == nil
+	for entityClass, count := range counts {
+		if current, ok := peak[entityClass]; !ok || count > current {
+			peak[entityClass] = count
+			changed = true
+		}
+		total += count
+	}
+	if total > p.PeakTotal {
+		p.PeakTotal = total
+		changed = true
+	}
+	p.Peak = peak
+	return p, changed
+}
@@ -0,0 +1,244 @@
+package licensing
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrUnknownLicense is returned when a usage report refers to a license that
+// was not issued by the issuer verifying it.
+var ErrUnknownLicense = errors.New("unknown license")
+
+// ID returns the identifier of the license, derived from its signature.
+func (f *LicenseFile) ID() string {
+	sum := sha256.Sum256(f.Signature)
+	return hex.EncodeToString(sum[:])
+}
+
+// UsageSample is a measurement of the number of entities at a point in time.
+type UsageSample struct {
+	// Time is the time at which the entities were counted.
+	Time Timestamp `json:"time"`
+	// Counts is the number of entities per entity class, per namespace.
+	Counts map[string]map[string]int `json:"counts"`
+}
+
+// UsageStats summarizes the number of entities over a period.
+type UsageStats struct {
+	// Peak is the highest number of entities per entity class.
+	Peak map[string]int `json:"peak"`
+	// Average is the average number of entities per entity class.
+	Average map[string]float64 `json:"average"`
+	// PeakTotal is the highest total number of entities.
+	PeakTotal int `json:"peakTotal"`
+	// AverageTotal is the average total number of entities.
+	AverageTotal float64 `json:"averageTotal"`
+}
+
+// UsageReport summarizes the entity usage of an installation over a period,
+// as evidence of the usage of a license.
+type UsageReport struct {
+	// Installation identifies the installation that produced the report.
+	Installation InstallationIdentity `json:"installation"`
+	// LicenseID is the ID of the license in use during the period.
+	LicenseID string `json:"licenseID"`
+	// AccountName is the name of the customer account.
+	AccountName string `json:"accountName"`
+	// AccountID is the ID of the customer account.
+	AccountID uint64 `json:"accountID"`
+	// PeriodStart is the beginning of the reported period.
+	PeriodStart Timestamp `json:"periodStart"`
+	// PeriodEnd is the end of the reported period, excluded.
+	PeriodEnd Timestamp `json:"periodEnd"`
+	// Samples is the number of samples the report was built from.
+	Samples int `json:"samples"`
+	// Usage is the usage of the whole installation.
+	Usage UsageStats `json:"usage"`
+	// Namespaces is the usage per namespace.
+	Namespaces map[string]UsageStats `json:"namespaces,omitempty"`
+	// SignatureOptions contains signature algorithm and related parameters,
+	// which are part of the signed report data.
+	SignatureOptions SignatureOptions `json:"signature"`
+}
+
+// SignedUsageReport is a usage report along with its signature by the
+// installation key.
+type SignedUsageReport struct {
+	// Report is the usage report.
+	Report UsageReport `json:"report"`
+	// Signature is the signature of the JSON encoded report.
+	Signature []byte `json:"signature"`
+}
+
+// usageAccumulator accumulates the peak and sum of entity counts.
+type usageAccumulator struct {
+	peak     map[string]int
+	sum      map[string]int
+	peakSum  int
+	totalSum int
+}
+
+func newUsageAccumulator() *usageAccumulator {
+	return &usageAccumulator{
+		peak: map[string]int{},
+		sum:  map[string]int{},
+	}
+}
+
+// add accumulates the entity counts per entity class of one sample.
+func (a *usageAccumulator) add(counts map[string]int) {
+	var total int
+	for _, count := range counts {
+		total += count
+	}
+	a.addPeak(counts, total)
+}
+
+// addPeak accumulates the entity counts per entity class and the total number
+// of entities of one sample, which may not be the sum of the counts when they
+// are peaks over a period.
+func (a *usageAccumulator) addPeak(counts map[string]int, total int) {
+	for entityClass, count := range counts {
+		if count > a.peak[entityClass] {
+			a.peak[entityClass] = count
+		}
+		a.sum[entityClass] += count
+	}
+	if total > a.peakSum {
+		a.peakSum = total
+	}
+	a.totalSum += total
+}
+
+// stats returns the usage statistics over the given number of samples.
+func (a *usageAccumulator) stats(samples int) UsageStats {
+	stats := UsageStats{
+		Peak:      a.peak,
+		Average:   make(map[string]float64, len(a.sum)),
+		PeakTotal: a.peakSum,
+	}
+	if samples == 0 {
+		return stats
+	}
+	for entityClass, sum := range a.sum {
+		stats.Average[entityClass] = float64(sum) / float64(samples)
+	}
+	stats.AverageTotal = float64(a.totalSum) / float64(samples)
+	return stats
+}
+
+// BuildUsageReport builds the usage report of the license by the given
+// installation over the period [start, end), from the samples taken during
+// that period. Samples outside of the period are ignored. Namespaces missing
+// from a sample count as having no entities at that time.
+func BuildUsageReport(file *LicenseFile, installation InstallationIdentity, start, end time.Time, samples []UsageSample) (*UsageReport, error) {
+	// timestamps are serialized with a precision of one second
+	start = start.Truncate(time.Second)
+	end = end.Truncate(time.Second)
+
+	inPeriod := make([]UsageSample, 0, len(samples))
+	for _, sample := range samples {
+		t := time.Time(sample.Time)
+		if !t.Before(start) && t.Before(end) {
+			inPeriod = append(inPeriod, sample)
+		}
+	}
+	sort.SliceStable(inPeriod, func(i, j int) bool {
+		return time.Time(inPeriod[i].Time).Before(time.Time(inPeriod[j].Time))
+	})
+
+	usage := newUsageAccumulator()
+	namespaces := map[string]*usageAccumulator{}
+	for _, sample := range inPeriod {
+		counts := map[string]int{}
+		for namespace, namespaceCounts := range sample.Counts {
+			accumulator, ok := namespaces[namespace]
+			if !ok {
+				accumulator = newUsageAccumulator()
+				namespaces[namespace] = accumulator
+			}
+			accumulator.add(namespaceCounts)
+			for entityClass, count := range namespaceCounts {
+				counts[entityClass] += count
+			}
+		}
+		usage.add(counts)
+	}
+
+	return newUsageReport(file, installation, start, end, len(inPeriod), usage, namespaces)
+}
+
+// newUsageReport creates the usage report of the license by the given
+// installation over the period [start, end), from the usage accumulated over
+// the given number of samples in total and per namespace.
+func newUsageReport(file *LicenseFile, installation InstallationIdentity, start, end time.Time, samples int, usage *usageAccumulator, namespaces map[string]*usageAccumulator) (*UsageReport, error) {
+	if installation.Fingerprint == "" {
+		return nil, errors.New("usage report requires the fingerprint of the installation")
+	}
+	// timestamps are serialized with a precision of one second
+	start = start.Truncate(time.Second)
+	end = end.Truncate(time.Second)
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid usage report period: %s to %s", start, end)
+	}
+
+	report := &UsageReport{
+		Installation:     installation,
+		LicenseID:        file.ID(),
+		AccountName:      file.License.AccountName,
+		AccountID:        file.License.AccountID,
+		PeriodStart:      Timestamp(start),
+		PeriodEnd:        Timestamp(end),
+		Samples:          samples,
+		Usage:            usage.stats(samples),
+		SignatureOptions: DefaultSignatureOptions,
+	}
+	if len(namespaces) > 0 {
+		report.Namespaces = make(map[string]UsageStats, len(namespaces))
+		for namespace, accumulator := range namespaces {
+			report.Namespaces[namespace] = accumulator.stats(samples)
+		}
+	}
+	return report, nil
+}
+
+// SignUsageReport signs the usage report with the PEM encoded private key of
+// the installation, by using the signature options specified in the report.
+func SignUsageReport(report *UsageReport, privateKeyPem string) (*SignedUsageReport, error) {
+	pk, err := LoadPrivateKey(privateKeyPem)
+	if err != nil {
+		return nil, err
+	}
+	return SignUsageReportWithSigner(report, pk)
+}
+
+// SignUsageReportWithSigner signs the usage report with the given signer,
+// which must hold an RSA key, by using the signature options specified in the
+// report.
+func SignUsageReportWithSigner(report *UsageReport, signer crypto.Signer) (*SignedUsageReport, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signData(data, signer, &report.SignatureOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedUsageReport{Report: *report, Signature: signature}, nil
+}
+
+// Verify verifies the signature of the usage report with the PEM encoded
+// public key of the installation.
+func (r *SignedUsageReport) Verify(publicKeyPem string) error {
+	data, err := json.Marshal(&r.Report)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(data, r.Signature, r.Report.SignatureOptions, publicKeyPem)
+}
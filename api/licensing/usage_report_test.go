@@ -0,0 +1,167 @@
+package licensing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUsageReport(t *testing.T) {
+	file := signedMockLicenseFile(t, "Acme Corp.", now.Add(48*time.Hour))
+	samples := []UsageSample{
+		{
+			Time: Timestamp(now.Add(-time.Hour)),
+			Counts: map[string]map[string]int{
+				"default": {"agent": 100},
+			},
+		},
+		{
+			Time: Timestamp(now),
+			Counts: map[string]map[string]int{
+				"default": {"agent": 10, "proxy": 4},
+				"dev":     {"agent": 2},
+			},
+		},
+		{
+			Time: Timestamp(now.Add(time.Hour)),
+			Counts: map[string]map[string]int{
+				"default": {"agent": 20, "proxy": 2},
+			},
+		},
+	}
+
+	installation := InstallationIdentity{ClusterID: "cluster-1", Fingerprint: "install-1"}
+	report, err := BuildUsageReport(file, installation, now, now.Add(2*time.Hour), samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, installation, report.Installation)
+	assert.Equal(t, file.ID(), report.LicenseID)
+	assert.Equal(t, "Acme Corp.", report.AccountName)
+	assert.Equal(t, 2, report.Samples, "samples outside of the period should be ignored")
+	assert.Equal(t, map[string]int{"agent": 20, "proxy": 4}, report.Usage.Peak)
+	assert.Equal(t, map[string]float64{"agent": 16, "proxy": 3}, report.Usage.Average)
+	assert.Equal(t, 22, report.Usage.PeakTotal)
+	assert.Equal(t, 19.0, report.Usage.AverageTotal)
+	assert.Equal(t, map[string]int{"agent": 2}, report.Namespaces["dev"].Peak)
+	assert.Equal(t, map[string]float64{"agent": 1}, report.Namespaces["dev"].Average)
+
+	_, err = BuildUsageReport(file, installation, now, now, samples)
+	assert.Error(t, err)
+	_, err = BuildUsageReport(file, InstallationIdentity{}, now, now.Add(time.Hour), samples)
+	assert.Error(t, err, "usage reports should identify the installation")
+}
+
+func TestSignUsageReport(t *testing.T) {
+	catalog, err := ParsePlanCatalog([]byte(testPlanCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := NewIssuer(catalog, key, NewMemoryStore())
+	file, err := issuer.Issue(context.Background(), &LicenseSpec{
+		Issuer:             "Sensu, Inc.",
+		AccountName:        "Acme Corp.",
+		AccountID:          573,
+		Plan:               "enterprise",
+		Binding:            &Binding{Fingerprints: []string{"install-1"}},
+		ActivationRequired: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	activate := func(file *LicenseFile, signer *rsa.PrivateKey, publicKey string) {
+		t.Helper()
+		request, err := NewActivationRequest(file, "install-1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed, err := request.Sign(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := issuer.Activate(context.Background(), signed, publicKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	installation := InstallationIdentity{Fingerprint: "install-1"}
+	report, err := BuildUsageReport(file, installation, now, now.Add(time.Hour), []UsageSample{
+		{Time: Timestamp(now), Counts: map[string]map[string]int{"default": {"agent": 10}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := SignUsageReport(report, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, signed.Verify(testPublicKey))
+	assert.ErrorIs(t, issuer.VerifyUsageReport(context.Background(), signed), ErrNotActivated)
+
+	// reports are verified with the key the installation was activated with
+	activate(file, key, testPublicKey)
+	assert.NoError(t, issuer.VerifyUsageReport(context.Background(), signed))
+
+	tampered := *signed
+	tampered.Report.Usage.PeakTotal = 1
+	assert.Error(t, tampered.Verify(testPublicKey))
+	assert.Error(t, issuer.VerifyUsageReport(context.Background(), &tampered))
+
+	// reports of other installations than the activated ones are refused
+	report.Installation.Fingerprint = "install-2"
+	signed, err = SignUsageReport(report, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.ErrorIs(t, issuer.VerifyUsageReport(context.Background(), signed), ErrNotActivated)
+
+	// reports signed with another key than the activated one are refused
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&otherKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherFile, err := issuer.Issue(context.Background(), &LicenseSpec{
+		Issuer:             "Sensu, Inc.",
+		AccountName:        "Acme Corp.",
+		AccountID:          573,
+		Plan:               "enterprise",
+		ActivationRequired: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	activate(otherFile, otherKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	otherReport, err := BuildUsageReport(otherFile, installation, now, now.Add(time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err = SignUsageReport(otherReport, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, issuer.VerifyUsageReport(context.Background(), signed))
+
+	other, err := BuildUsageReport(signedMockLicenseFile(t, "Acme Corp.", now.Add(time.Hour)), installation, now, now.Add(time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err = SignUsageReport(other, testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.ErrorIs(t, issuer.VerifyUsageReport(context.Background(), signed), ErrUnknownLicense)
+}
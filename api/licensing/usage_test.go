@@ -0,0 +1,90 @@
+package licensing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageRecorder(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	recorder := NewUsageRecorder(store)
+	recorder.MaxHourlyRollups = 24
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	record := func(t *testing.T, at time.Time, agents, proxies int) {
+		t.Helper()
+		sample := UsageSample{
+			Time: Timestamp(at),
+			Counts: map[string]map[string]int{
+				"default": {"agent": agents},
+				"dev":     {"proxy": proxies},
+			},
+		}
+		if err := recorder.Record(ctx, sample); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// one sample every hour for 40 days, peaking on the first day
+	for i := 0; i < 40*24; i++ {
+		agents := 10 + i%24
+		if i == 5 {
+			agents = 500
+		}
+		record(t, day.Add(time.Duration(i)*time.Hour), agents, 3)
+	}
+	// a later sample of the same hour only raises the peak
+	last := day.Add(40*24*time.Hour - time.Hour)
+	record(t, last.Add(30*time.Minute), 50, 1)
+	record(t, last.Add(45*time.Minute), 20, 1)
+
+	hourly := recorder.Rollups(RollupHourly)
+	assert.Len(t, hourly, 24, "hourly rollups should be bounded")
+	assert.Equal(t, Timestamp(last), hourly[23].Start)
+	assert.Equal(t, map[string]int{"agent": 50, "proxy": 3}, hourly[23].Peak)
+	assert.Equal(t, 51, hourly[23].PeakTotal)
+	assert.Equal(t, map[string]UsagePeak{
+		"default": {Peak: map[string]int{"agent": 50}, PeakTotal: 50},
+		"dev":     {Peak: map[string]int{"proxy": 3}, PeakTotal: 3},
+	}, hourly[23].Namespaces)
+	assert.Len(t, recorder.Rollups(RollupDaily), 40)
+
+	end := day.Add(40 * 24 * time.Hour)
+	assert.Equal(t, 50, recorder.Peak("agent", end.Add(-time.Hour)))
+	assert.Equal(t, 50, recorder.Peak("agent", end.Add(-30*24*time.Hour)))
+	assert.Equal(t, 500, recorder.Peak("agent", day))
+	assert.Equal(t, 503, recorder.PeakTotal(day))
+	assert.Equal(t, 0, recorder.Peak("backend", day))
+
+	file := signedMockLicenseFile(t, "Acme Corp.", end)
+	installation := InstallationIdentity{Fingerprint: "install-1"}
+	report, err := recorder.Report(file, installation, day, day.Add(2*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, installation, report.Installation)
+	assert.Equal(t, 2, report.Samples, "the report should be built from the daily rollups")
+	assert.Equal(t, map[string]int{"agent": 500, "proxy": 3}, report.Usage.Peak)
+	assert.Equal(t, 503, report.Usage.PeakTotal)
+	assert.Equal(t, map[string]int{"agent": 500}, report.Namespaces["default"].Peak)
+	assert.Equal(t, map[string]float64{"proxy": 3}, report.Namespaces["dev"].Average)
+
+	report, err = recorder.Report(file, installation, end.Add(-2*time.Hour), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, report.Samples, "the report should be built from the hourly rollups")
+	assert.Equal(t, map[string]int{"agent": 50, "proxy": 3}, report.Usage.Peak)
+
+	// the rollups are persisted
+	loaded := NewUsageRecorder(store)
+	if err := loaded.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, recorder.Rollups(RollupHourly), loaded.Rollups(RollupHourly))
+	assert.Equal(t, recorder.Rollups(RollupDaily), loaded.Rollups(RollupDaily))
+}
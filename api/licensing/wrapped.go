@@ -0,0 +1,76 @@
+package licensing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sensu/core/v3/types"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalWrappedJSON encodes the license file in JSON, wrapped in a
+// types.Wrapper as consumed by sensuctl create.
+func MarshalWrappedJSON(f *LicenseFile) ([]byte, error) {
+	return json.Marshal(types.WrapResource(f))
+}
+
+// UnmarshalWrappedJSON decodes a license file from JSON wrapped in a
+// types.Wrapper.
+func UnmarshalWrappedJSON(data []byte) (*LicenseFile, error) {
+	wrapper := &types.Wrapper{}
+	if err := json.Unmarshal(data, wrapper); err != nil {
+		return nil, err
+	}
+	file, ok := wrapper.Value.(*LicenseFile)
+	if !ok {
+		return nil, errors.New("wrapped resource is not a license file")
+	}
+	return file, nil
+}
+
+// MarshalWrappedYAML encodes the license file in YAML, wrapped in a
+// types.Wrapper as consumed by sensuctl create. The wrapped JSON encoding is
+// converted to YAML, so that the license is decoded as in JSON and its
+// signature can be verified after the round trip.
+func MarshalWrappedYAML(f *LicenseFile) ([]byte, error) {
+	data, err := MarshalWrappedJSON(f)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, which keeps the order of fields and the types of
+	// values, unlike types.Wrapper.MarshalYAML which quotes numbers
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	resetStyle(&document)
+	return yaml.Marshal(&document)
+}
+
+// resetStyle resets the JSON style of the node and its children to the default
+// block style of YAML.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// UnmarshalWrappedYAML decodes a license file from YAML wrapped in a
+// types.Wrapper. The YAML document is converted to JSON first, as sensuctl
+// does, so that the license is decoded as in JSON.
+func UnmarshalWrappedYAML(data []byte) (*LicenseFile, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("cannot parse wrapped license file: %w", err)
+	}
+	if document == nil {
+		return nil, errors.New("empty wrapped license file")
+	}
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert wrapped license file to JSON: %w", err)
+	}
+	return UnmarshalWrappedJSON(jsonData)
+}
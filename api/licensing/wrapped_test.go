@@ -0,0 +1,139 @@
+package licensing
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/core/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenLicenseFile returns the license file of the golden files, signed with
+// the test key.
+func goldenLicenseFile(t *testing.T) *LicenseFile {
+	t.Helper()
+	file := &LicenseFile{
+		License: License{
+			Version:          1,
+			Issuer:           "Sensu, Inc.",
+			AccountName:      "Acme Corp.",
+			AccountID:        573,
+			Issued:           Timestamp(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			ValidUntil:       Timestamp(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Plan:             "enterprise",
+			Features:         FeatureList{"rbac", "ldap"},
+			SignatureOptions: DefaultSignatureOptions,
+			EntityLimit:      100,
+			EntityClassLimits: map[string]int{
+				"agent": 80,
+			},
+		},
+	}
+	file.SetObjectMeta(corev2.ObjectMeta{
+		Labels:      map[string]string{"team": "ops"},
+		Annotations: map[string]string{"ticket": "42"},
+	})
+	if err := SignLicenseFile(file, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// readGolden reads the golden file, or updates it with data when the -update
+// flag is set.
+func readGolden(t *testing.T, name string, data func() []byte) []byte {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, data(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return golden
+}
+
+func TestWrappedRoundTrip(t *testing.T) {
+	var generated *LicenseFile
+	generate := func(marshal func(*LicenseFile) ([]byte, error)) func() []byte {
+		return func() []byte {
+			if generated == nil {
+				generated = goldenLicenseFile(t)
+			}
+			data, err := marshal(generated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return data
+		}
+	}
+	validator := &Validator{PublicKey: testPublicKey}
+
+	goldenJSON := readGolden(t, "license_file.json", generate(MarshalWrappedJSON))
+	goldenYAML := readGolden(t, "license_file.yaml", generate(MarshalWrappedYAML))
+
+	fromJSON, err := UnmarshalWrappedJSON(goldenJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, validator.Validate(fromJSON))
+	assert.Equal(t, "ops", fromJSON.GetObjectMeta().Labels["team"])
+	data, err := MarshalWrappedJSON(fromJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(goldenJSON), string(data))
+
+	fromYAML, err := UnmarshalWrappedYAML(goldenYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, validator.Validate(fromYAML))
+	assert.Equal(t, fromJSON, fromYAML)
+	data, err = MarshalWrappedYAML(fromYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(goldenYAML), string(data))
+
+	_, err = UnmarshalWrappedYAML([]byte("type: Entity\napi_version: core/v2\nspec: {}\n"))
+	assert.Error(t, err)
+	_, err = UnmarshalWrappedYAML(nil)
+	assert.Error(t, err)
+}
+
+func TestLicenseYAML(t *testing.T) {
+	file := goldenLicenseFile(t)
+	data, err := yaml.Marshal(file.License)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, bytes.Contains(data, []byte("hashAlgorithm: SHA256")), string(data))
+	assert.True(t, bytes.Contains(data, []byte("validUntil: \"2100-01-01T00:00:00Z\"")), string(data))
+
+	license := License{}
+	if err := yaml.Unmarshal(data, &license); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.License, license)
+
+	// unquoted timestamps are accepted as well
+	var ts Timestamp
+	if err := yaml.Unmarshal([]byte("2100-01-01T00:00:00Z"), &ts); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file.License.ValidUntil, ts)
+
+	var hash HashAlgorithm
+	assert.Error(t, yaml.Unmarshal([]byte("md5"), &hash))
+}
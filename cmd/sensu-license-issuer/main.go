@@ -0,0 +1,58 @@
+// Command sensu-license-issuer runs a local license issuing service, which
+// mints licenses signed with the provided private key from license specs
+// POSTed to /licenses. Issued licenses are recorded in the -data directory,
+// or only kept in memory when it is not set.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/sensu/sensu-licensing/v2/api/licensing"
+)
+
+var (
+	listenAddr  = flag.String("listen", "127.0.0.1:8080", "Address to listen on")
+	keyPath     = flag.String("key", "", "Path to the PEM-encoded RSA private signing key")
+	catalogPath = flag.String("plans", "", "Path to the YAML plan catalog")
+	dataDir     = flag.String("data", "", "Directory where issued licenses are recorded")
+)
+
+func main() {
+	flag.Parse()
+	if *keyPath == "" || *catalogPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	keyData, err := os.ReadFile(*keyPath)
+	if err != nil {
+		log.Fatalf("fatal error reading private key: %s", err)
+	}
+	key, err := licensing.LoadPrivateKey(string(keyData))
+	if err != nil {
+		log.Fatalf("fatal error loading private key: %s", err)
+	}
+
+	catalogData, err := os.ReadFile(*catalogPath)
+	if err != nil {
+		log.Fatalf("fatal error reading plan catalog: %s", err)
+	}
+	catalog, err := licensing.ParsePlanCatalog(catalogData)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var store licensing.Store
+	if *dataDir != "" {
+		store = licensing.NewFileStore(*dataDir)
+	} else {
+		log.Printf("no data directory set, issued licenses will not survive a restart")
+		store = licensing.NewMemoryStore()
+	}
+
+	log.Printf("issuing licenses on http://%s%s", *listenAddr, licensing.IssuerPath)
+	log.Fatal(http.ListenAndServe(*listenAddr, licensing.NewIssuer(catalog, key, store)))
+}
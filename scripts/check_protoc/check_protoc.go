@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"regexp"
+)
+
+// libprotocRe matches the version of protoc the protobuf messages are
+// generated with, which is the version used by sensu/core.
+var libprotocRe = regexp.MustCompile(`^libprotoc 3\.19\.4\s*$`)
+
+func main() {
+	out, err := exec.Command("protoc", "--version").Output()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := checkVersion(out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// checkVersion returns an error unless out is the output of protoc --version
+// for the expected version.
+func checkVersion(out []byte) error {
+	if !libprotocRe.Match(out) {
+		return fmt.Errorf("bad protoc version: want %q, got %q", libprotocRe.String(), string(out))
+	}
+	return nil
+}
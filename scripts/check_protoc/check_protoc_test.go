@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, checkVersion([]byte("libprotoc 3.19.4\n")))
+	assert.Error(t, checkVersion([]byte("libprotoc 3.21.12\n")))
+	assert.Error(t, checkVersion([]byte("libprotoc 3.19.40\n")))
+	assert.Error(t, checkVersion(nil))
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	corev2 "github.com/sensu/core/v2"
+	"github.com/sensu/sensu-licensing/v2/api/licensing"
+)
+
+var (
+	pkgDir = flag.String("d", ".", "Path to the licensing package, for doc comments")
+	output = flag.String("o", "", "Path to output file")
+)
+
+// overrides are the schemas of the types with custom JSON encodings.
+var overrides = map[reflect.Type]map[string]interface{}{
+	reflect.TypeOf(licensing.Timestamp{}): {
+		"type":        "string",
+		"format":      "date-time",
+		"description": "RFC 3339 timestamp, e.g. 2018-07-26T12:12:06-04:00",
+	},
+	reflect.TypeOf(licensing.HashAlgorithm(0)): {
+		"type": "string",
+		"enum": licensing.SupportedHashAlgorithms(),
+	},
+	reflect.TypeOf(licensing.Duration(0)): {
+		"type":        "string",
+		"pattern":     licensing.DurationPattern,
+		"description": "duration in days, e.g. 30d, or in the format of Go durations, e.g. 72h",
+	},
+	reflect.TypeOf(licensing.OverageMode("")): {
+		"type": "string",
+		"enum": []licensing.OverageMode{licensing.OverageBlock, licensing.OverageWarn, licensing.OverageBurst},
+	},
+}
+
+// generator generates the JSON schema of Go types from their JSON encoding.
+type generator struct {
+	defs map[string]interface{}
+	docs map[string]string
+}
+
+func main() {
+	flag.Parse()
+	schema, err := generateSchema(*pkgDir)
+	if err != nil {
+		log.Fatalf("fatal error generating the schema: %s", err)
+	}
+	if err := os.WriteFile(*output, schema, 0o644); err != nil {
+		log.Fatalf("fatal error writing %s: %s", *output, err)
+	}
+}
+
+// generateSchema generates the JSON schema of license files, with the doc
+// comments of the licensing package in the given directory.
+func generateSchema(dir string) ([]byte, error) {
+	docs, err := parseDocs(dir)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		defs: make(map[string]interface{}),
+		docs: docs,
+	}
+	root := g.schemaOf(reflect.TypeOf(licensing.LicenseFile{}))
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "Sensu license file"
+	root["$defs"] = g.defs
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaOf returns the schema of the JSON encoding of values of type t.
+func (g *generator) schemaOf(t reflect.Type) map[string]interface{} {
+	if schema, ok := overrides[t]; ok {
+		return schema
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaOf(t.Elem())
+	case reflect.Struct:
+		return g.ref(t)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": g.schemaOf(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	}
+	log.Fatalf("unsupported type %s", t)
+	return nil
+}
+
+// ref adds the schema of the struct type t to the definitions, and returns a
+// reference to it.
+func (g *generator) ref(t reflect.Type) map[string]interface{} {
+	ref := map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	if _, ok := g.defs[t.Name()]; ok {
+		return ref
+	}
+	properties := map[string]interface{}{}
+	required := []string{}
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if doc := g.docs[t.Name()]; doc != "" && t.PkgPath() == reflect.TypeOf(licensing.License{}).PkgPath() {
+		schema["description"] = doc
+	}
+	g.defs[t.Name()] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := map[string]interface{}{}
+		for k, v := range g.schemaOf(field.Type) {
+			property[k] = v
+		}
+		if doc := g.docs[t.Name()+"."+field.Name]; doc != "" && t.PkgPath() == reflect.TypeOf(licensing.License{}).PkgPath() {
+			property["description"] = doc
+		}
+		properties[name] = property
+		// the metadata of license files is set by the store rather than by
+		// issuers
+		if options != "omitempty" && field.Type != reflect.TypeOf(corev2.ObjectMeta{}) {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return ref
+}
+
+// parseDocs returns the doc comments of the types of the package in the given
+// directory, and of their fields, keyed by Type and Type.Field.
+func parseDocs(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, typ := range doc.New(pkg, "", doc.AllDecls).Types {
+			docs[typ.Name] = oneLine(typ.Doc)
+			for _, spec := range typ.Decl.Specs {
+				structType, ok := spec.(*ast.TypeSpec).Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						docs[typ.Name+"."+name.Name] = oneLine(field.Doc.Text())
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// oneLine joins the lines of a doc comment.
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
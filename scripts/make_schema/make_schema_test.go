@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSchemaUpToDate(t *testing.T) {
+	const dir = "../../api/licensing"
+	want, err := generateSchema(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(dir + "/license.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatal("license.schema.json is out of date, run go generate ./api/licensing")
+	}
+}